@@ -39,25 +39,69 @@ var (
 		Name:  "es",
 		Usage: "Initializes the Elasticsearch index.",
 	}
+	esReindexFlag = &cli.BoolFlag{
+		Name:  "es-reindex",
+		Usage: "Rebuilds the Elasticsearch index with the current mapping and analyzers, then switches the alias without downtime.",
+	}
 	esExportFlag = &cli.BoolFlag{
 		Name:  "es-export",
-		Usage: "Exports data from Elasticsearch to a specified file.",
+		Usage: "Exports the article index to an NDJSON file, keeping document IDs.",
 	}
 	esImportFlag = &cli.StringFlag{
 		Name:  "es-import",
-		Usage: "Imports data into Elasticsearch from a specified file.",
+		Usage: "Imports articles into Elasticsearch from an NDJSON file created by --es-export.",
+	}
+	mdImportFlag = &cli.StringFlag{
+		Name:  "md-import",
+		Usage: "Imports Hexo/Hugo Markdown posts with YAML/TOML front matter from a directory, keeping their original dates.",
+	}
+	mdExportFlag = &cli.StringFlag{
+		Name:  "md-export",
+		Usage: "Exports articles as a zip of Markdown files with front matter and their images: 'all' or an article ID.",
+	}
+	reconcileFlag = &cli.StringFlag{
+		Name:  "reconcile",
+		Usage: "Reconciles category and tag counts against Elasticsearch: 'check' reports drift, 'repair' also fixes the MySQL tables.",
 	}
 	adminFlag = &cli.BoolFlag{
 		Name:  "admin",
 		Usage: "Creates an administrator using the name, email and address specified in the configs.yaml file.",
 	}
+
+	// 以下为命令的选项标志，需与对应命令一起使用
+	batchSizeFlag = &cli.IntFlag{
+		Name:  "batch-size",
+		Usage: "Number of documents per bulk request, used with --es-import.",
+		Value: defaultEsImportBatchSize,
+	}
+	resumeFlag = &cli.BoolFlag{
+		Name:  "resume",
+		Usage: "Resumes an interrupted import from its progress file, used with --es-import.",
+	}
+	authorFlag = &cli.StringFlag{
+		Name:  "author",
+		Usage: "Username or email of the author of imported posts, used with --md-import. Defaults to website.email.",
+	}
+	dryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only reports what would be imported without writing anything, used with --md-import.",
+	}
 )
 
+// optionFlags 选项标志，不算作独立命令
+var optionFlags = []cli.Flag{batchSizeFlag, resumeFlag, authorFlag, dryRunFlag}
+
 // Run 执行基于命令行标志的相应操作
 // 它处理不同的标志，执行相应操作，并记录成功或错误的消息
 func Run(c *cli.Context) {
-	// 检查是否设置了多个标志
-	if c.NumFlags() > 1 {
+	// 检查是否设置了多个命令标志（选项标志不计入）
+	commands := c.NumFlags()
+	for _, f := range optionFlags {
+		if c.IsSet(f.GetName()) {
+			commands--
+		}
+	}
+	if commands > 1 {
 		err := cli.NewExitError("Only one command can be specified", 1)
 		global.Log.Error("Invalid command usage:", zap.Error(err))
 		os.Exit(1)
@@ -93,6 +137,42 @@ func Run(c *cli.Context) {
 		} else {
 			global.Log.Info("Successfully created ES indices")
 		}
+	case c.Bool(esReindexFlag.Name):
+		if err := ElasticsearchReindex(); err != nil {
+			global.Log.Error("Failed to reindex ES indices:", zap.Error(err))
+		} else {
+			global.Log.Info("Successfully reindexed ES indices")
+		}
+	case c.Bool(esExportFlag.Name):
+		if err := ElasticsearchExport(); err != nil {
+			global.Log.Error("Failed to export ES data:", zap.Error(err))
+		} else {
+			global.Log.Info("Successfully exported ES data")
+		}
+	case c.IsSet(esImportFlag.Name):
+		if err := ElasticsearchImport(c.String(esImportFlag.Name), c.Int(batchSizeFlag.Name), c.Bool(resumeFlag.Name)); err != nil {
+			global.Log.Error("Failed to import ES data:", zap.Error(err))
+		} else {
+			global.Log.Info("Successfully imported ES data")
+		}
+	case c.IsSet(mdImportFlag.Name):
+		if err := MarkdownImport(c.String(mdImportFlag.Name), c.String(authorFlag.Name), c.Bool(dryRunFlag.Name)); err != nil {
+			global.Log.Error("Failed to import Markdown posts:", zap.Error(err))
+		} else {
+			global.Log.Info("Successfully imported Markdown posts")
+		}
+	case c.IsSet(mdExportFlag.Name):
+		if err := MarkdownExport(c.String(mdExportFlag.Name)); err != nil {
+			global.Log.Error("Failed to export Markdown posts:", zap.Error(err))
+		} else {
+			global.Log.Info("Successfully exported Markdown posts")
+		}
+	case c.IsSet(reconcileFlag.Name):
+		if err := Reconcile(c.String(reconcileFlag.Name)); err != nil {
+			global.Log.Error("Failed to reconcile category and tag counts:", zap.Error(err))
+		} else {
+			global.Log.Info("Successfully reconciled category and tag counts")
+		}
 	default:
 		err := cli.NewExitError("unknown command", 1)
 		global.Log.Error(err.Error(), zap.Error(err))
@@ -111,9 +191,17 @@ func NewApp() *cli.App {
 		sqlExportFlag, // --sql-export
 		sqlImportFlag, // --sql-import
 		esFlag,        // --es
+		esReindexFlag, // --es-reindex
 		esExportFlag,  // --es-export
 		esImportFlag,  // --es-import
+		mdImportFlag,  // --md-import
+		mdExportFlag,  // --md-export
+		reconcileFlag, // --reconcile
 		adminFlag,     // --admin
+		batchSizeFlag, // --batch-size
+		resumeFlag,    // --resume
+		authorFlag,    // --author
+		dryRunFlag,    // --dry-run
 	}
 	app.Action = Run
 	return app
@@ -2,13 +2,17 @@ package flag
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"personal_blog/global"
 	esModel "personal_blog/internal/model/elasticsearch"
 	esutil "personal_blog/pkg/elasticSearch"
 )
 
 // Elasticsearch 创建 ES 索引
+// - 物理索引带版本号（article_index_v1），读写都通过别名 article_index 进行
+// - 已存在时删除别名下的全部物理索引后重建，会清空数据；仅调整 mapping/分词器时请使用 --es-reindex
 func Elasticsearch() error {
 
 	// 检查索引是否已存在
@@ -20,6 +24,7 @@ func Elasticsearch() error {
 	if indexExists {
 		// 打印提示信息
 		fmt.Println("The index already exists. Do you want to delete the data and recreate the index? (y/n)")
+		fmt.Println("To change the mapping or analyzers without losing data, use --es-reindex instead.")
 
 		// 读取用户输入
 		scanner := bufio.NewScanner(os.Stdin)
@@ -28,11 +33,17 @@ func Elasticsearch() error {
 
 		switch input {
 		case "y":
-			// 如果用户输入 y，删除索引
+			// 如果用户输入 y，删除别名下的全部物理索引（早期部署中为同名物理索引本身）
 			fmt.Println("Proceeding to delete the data and recreate the index...")
-			if err := esutil.IndexDelete(esModel.ArticleIndex()); err != nil {
+			indices, err := esutil.ResolveIndices(context.TODO(), esModel.ArticleIndex())
+			if err != nil {
 				return err
 			}
+			for _, index := range indices {
+				if err := esutil.IndexDelete(index); err != nil {
+					return err
+				}
+			}
 		case "n":
 			// 如果用户输入 n，退出程序
 			fmt.Println("Exiting the program.")
@@ -44,6 +55,34 @@ func Elasticsearch() error {
 		}
 	}
 
-	// 创建索引
-	return esutil.IndexCreate(esModel.ArticleIndex(), esModel.ArticleMapping())
+	analyzer, searchAnalyzer, err := resolveArticleAnalyzers(context.TODO())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Creating index with analyzer=%q, search_analyzer=%q\n", analyzer, searchAnalyzer)
+
+	// 创建第一个版本的物理索引，并挂上别名
+	return esutil.IndexCreateWithAlias(
+		esModel.ArticleIndexVersion(1),
+		esModel.ArticleIndex(),
+		esModel.ArticleMapping(analyzer, searchAnalyzer),
+	)
+}
+
+// resolveArticleAnalyzers 解析文章索引的分词器：配置的分词器插件确认缺失时回退为 standard，无法确认时返回错误
+func resolveArticleAnalyzers(ctx context.Context) (analyzer, searchAnalyzer string, err error) {
+	if analyzer, err = esutil.ResolveAnalyzer(ctx, global.Config.ES.Analyzer); err != nil {
+		return "", "", err
+	}
+	searchAnalyzer = global.Config.ES.SearchAnalyzer
+	if searchAnalyzer == "" {
+		searchAnalyzer = analyzer
+	} else if searchAnalyzer, err = esutil.ResolveAnalyzer(ctx, searchAnalyzer); err != nil {
+		return "", "", err
+	}
+	// 索引分词器已回退时，搜索分词器也保持一致，避免两者词元不匹配
+	if analyzer == esutil.DefaultAnalyzer {
+		searchAnalyzer = analyzer
+	}
+	return analyzer, searchAnalyzer, nil
 }
@@ -0,0 +1,165 @@
+package flag
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	esModel "personal_blog/internal/model/elasticsearch"
+	esutil "personal_blog/pkg/elasticSearch"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// defaultEsImportBatchSize 导入时每个 bulk 请求默认包含的文档数
+const defaultEsImportBatchSize = 500
+
+// esDumpRecord 导出文件中的一行：文档ID + 原始文档
+type esDumpRecord struct {
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// ElasticsearchExport 导出文章索引为 NDJSON 文件（每行一篇文章，保留文档ID）
+func ElasticsearchExport() error {
+	// 路径
+	timer := time.Now().Format("20060102")
+	esPath := fmt.Sprintf("es_%s.ndjson", timer)
+
+	// 创建文件
+	outFile, err := os.Create(esPath)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+	writer := bufio.NewWriter(outFile)
+	encoder := json.NewEncoder(writer)
+
+	// 滚动读取全部文章，逐行写入
+	count := 0
+	err = esutil.ScrollHits(context.TODO(), esModel.ArticleIndex(), nil, true, func(hits []types.Hit) error {
+		for _, hit := range hits {
+			if hit.Id_ == nil {
+				continue
+			}
+			if err := encoder.Encode(esDumpRecord{ID: *hit.Id_, Source: hit.Source_}); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d articles to %s\n", count, esPath)
+	return nil
+}
+
+// ElasticsearchImport 从 --es-export 导出的 NDJSON 文件批量导入文章，已存在的同ID文章会被覆盖
+// - 每个批次成功后把已导入的行数写入进度文件（<文件名>.progress），全部完成后删除
+// - resume 为 true 时从进度文件记录的位置继续，跳过已导入的行
+func ElasticsearchImport(esPath string, batchSize int, resume bool) error {
+	if batchSize < 1 {
+		batchSize = defaultEsImportBatchSize
+	}
+	progressPath := esPath + ".progress"
+
+	// 1、确定起始位置
+	skip := 0
+	if resume {
+		var err error
+		if skip, err = readImportProgress(progressPath); err != nil {
+			return err
+		}
+		fmt.Printf("Resuming import from line %d\n", skip+1)
+	}
+
+	// 2、逐行读取，攒满一批写入一次
+	inFile, err := os.Open(esPath)
+	if err != nil {
+		return err
+	}
+	defer inFile.Close()
+	reader := bufio.NewReader(inFile)
+
+	ctx := context.TODO()
+	line := 0
+	imported := 0
+	batch := make([]esutil.BulkDoc, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := esutil.BulkIndex(ctx, batch); err != nil {
+			return fmt.Errorf("import lines %d-%d: %w (rerun with --resume to continue)", line-len(batch)+1, line, err)
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return os.WriteFile(progressPath, []byte(strconv.Itoa(line)), 0644)
+	}
+	for {
+		raw, readErr := reader.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return readErr
+		}
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 {
+			line++
+			if line > skip {
+				var record esDumpRecord
+				if err := json.Unmarshal(raw, &record); err != nil {
+					return fmt.Errorf("parse line %d: %w", line, err)
+				}
+				if record.ID == "" || len(record.Source) == 0 {
+					return fmt.Errorf("parse line %d: missing _id or _source", line)
+				}
+				batch = append(batch, esutil.BulkDoc{ID: record.ID, Source: record.Source})
+				if len(batch) >= batchSize {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// 3、全部完成，清理进度文件
+	if err := os.Remove(progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	fmt.Printf("Imported %d articles from %s\n", imported, esPath)
+	return nil
+}
+
+// readImportProgress 读取进度文件中已导入的行数，文件不存在时从头开始
+func readImportProgress(progressPath string) (int, error) {
+	data, err := os.ReadFile(progressPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	skip, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || skip < 0 {
+		return 0, fmt.Errorf("invalid progress file %s", progressPath)
+	}
+	return skip, nil
+}
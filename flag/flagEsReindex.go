@@ -0,0 +1,138 @@
+package flag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	esModel "personal_blog/internal/model/elasticsearch"
+	esutil "personal_blog/pkg/elasticSearch"
+)
+
+// ElasticsearchReindex 按当前配置的 mapping/分词器重建文章索引，不停机、不丢数据
+//  1. 以当前最大版本号 +1 创建新的物理索引
+//  2. 将别名下的全部文档复制到新索引
+//  3. 阻塞旧索引的写入，以外部版本号再复制一次，追平复制期间旧索引上的写入，并删除复制期间已被删除的文档
+//  4. 原子地把别名切换到新索引，之后的读写都落到新索引
+//
+// 阻塞期间文章的写入会失败，由发件箱在切换后重试写入新索引；浏览量、评论数等计数更新可能短暂失败
+// 旧索引仅解除别名、保留数据并保持只读，确认无误后可手动删除
+// 早期部署中 article_index 是物理索引而非别名，别名无法与其同名共存，因此在切换的同时删除它
+func ElasticsearchReindex() error {
+	ctx := context.TODO()
+	alias := esModel.ArticleIndex()
+
+	// 1、解析别名当前指向的物理索引
+	exists, err := esutil.IndexExists(alias)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("the article index does not exist, run --es first")
+	}
+	olds, err := esutil.ResolveIndices(ctx, alias)
+	if err != nil {
+		return err
+	}
+	legacy := len(olds) == 1 && olds[0] == alias
+
+	// 2、创建新版本的物理索引
+	version := 1
+	for _, index := range olds {
+		if v, ok := esModel.ParseArticleIndexVersion(index); ok && v >= version {
+			version = v + 1
+		}
+	}
+	newIndex := esModel.ArticleIndexVersion(version)
+	analyzer, searchAnalyzer, err := resolveArticleAnalyzers(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Creating index %s with analyzer=%q, search_analyzer=%q\n", newIndex, analyzer, searchAnalyzer)
+	if err := esutil.IndexCreate(newIndex, esModel.ArticleMapping(analyzer, searchAnalyzer)); err != nil {
+		return err
+	}
+
+	// 3、全量复制（不阻塞写入）
+	created, _, err := esutil.Reindex(ctx, olds, newIndex)
+	if err != nil {
+		return fmt.Errorf("copy documents from %s: %w", strings.Join(olds, ","), err)
+	}
+	fmt.Printf("Copied %d documents from %s to %s\n", created, strings.Join(olds, ","), newIndex)
+
+	// 4、阻塞旧索引的写入后追平：旧索引不再变化，新索引在切换前也没有其他写入，两者对比的结果是完整的
+	if err := esutil.SetWriteBlock(ctx, olds, true); err != nil {
+		return fmt.Errorf("block writes to %s: %w", strings.Join(olds, ","), err)
+	}
+	swapped := false
+	defer func() {
+		// 切换失败时解除阻塞，恢复旧索引的写入
+		if !swapped {
+			if err := esutil.SetWriteBlock(context.TODO(), olds, false); err != nil {
+				fmt.Printf("Failed to unblock writes to %s, unblock it manually: %v\n", strings.Join(olds, ","), err)
+			}
+		}
+	}()
+	if err := catchUpReindex(ctx, olds, newIndex); err != nil {
+		return err
+	}
+
+	// 5、切换别名
+	// 5.a 早期物理索引：切换别名的同时删除旧索引
+	if legacy {
+		if err := esutil.SwapAlias(ctx, alias, newIndex, nil, olds); err != nil {
+			return fmt.Errorf("swap alias %s to %s: %w", alias, newIndex, err)
+		}
+		swapped = true
+		fmt.Printf("Alias %s now points to %s, legacy index %s has been removed\n", alias, newIndex, alias)
+		return nil
+	}
+	// 5.b 带版本号的索引：旧索引解除别名后保持只读
+	if err := esutil.SwapAlias(ctx, alias, newIndex, olds, nil); err != nil {
+		return fmt.Errorf("swap alias %s to %s: %w", alias, newIndex, err)
+	}
+	swapped = true
+	fmt.Printf("Alias %s now points to %s\n", alias, newIndex)
+	fmt.Printf("Old indices %s are kept read-only without alias, delete them manually once verified\n", strings.Join(olds, ","))
+	return nil
+}
+
+// catchUpReindex 追平全量复制期间旧索引上的变更（调用前须阻塞旧索引的写入）
+// - 新增与修改：以外部版本号再复制一次，只覆盖旧索引中版本更高的文档
+// - 删除：新索引中存在而旧索引中已不存在的文档，从新索引中删除
+func catchUpReindex(
+	ctx context.Context,
+	olds []string,
+	newIndex string,
+) error {
+	// 1、追平新增与修改
+	created, updated, err := esutil.Reindex(ctx, olds, newIndex)
+	if err != nil {
+		return fmt.Errorf("catch up documents to %s: %w", newIndex, err)
+	}
+	// 2、追平删除
+	oldIDs, err := esutil.ScrollIDs(ctx, strings.Join(olds, ","), nil)
+	if err != nil {
+		return fmt.Errorf("list documents of %s: %w", strings.Join(olds, ","), err)
+	}
+	newIDs, err := esutil.ScrollIDs(ctx, newIndex, nil)
+	if err != nil {
+		return fmt.Errorf("list documents of %s: %w", newIndex, err)
+	}
+	kept := make(map[string]struct{}, len(oldIDs))
+	for _, id := range oldIDs {
+		kept[id] = struct{}{}
+	}
+	var removed []string
+	for _, id := range newIDs {
+		if _, ok := kept[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	if err := esutil.DeleteFromIndex(ctx, newIndex, removed); err != nil {
+		return fmt.Errorf("remove deleted documents from %s: %w", newIndex, err)
+	}
+	fmt.Printf("Caught up %s: %d created, %d updated, %d deleted\n", newIndex, created, updated, len(removed))
+	return nil
+}
@@ -0,0 +1,58 @@
+package flag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"personal_blog/global"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/adapter"
+	"personal_blog/internal/service/system"
+)
+
+// MarkdownExport 将文章导出为 Markdown 压缩包，target 为 "all" 时导出全部文章，否则为文章ID
+// - 每篇文章一个目录，包含带前言的 index.md 与下载到本地的封面、插图，可直接用 --md-import 重新导入
+func MarkdownExport(target string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	// 1、确定导出范围与文件名
+	id := target
+	zipPath := fmt.Sprintf("articles_%s.zip", time.Now().Format("20060102"))
+	if target == "all" {
+		id = ""
+	} else {
+		zipPath = fmt.Sprintf("article_%s.zip", id)
+	}
+
+	// 2、初始化服务（命令行标志在 Repository 层初始化之前执行，这里单独初始化）
+	mysqlAdapter := &adapter.MySQLAdapter{}
+	mysqlAdapter.SetConnection(global.DB)
+	repository.InitRepositoryGroupWithAdapter(mysqlAdapter)
+	// 命令行导出不做权限校验，无需注入文章服务
+	exportSvc := system.NewArticleExportSvc(repository.GroupApp, nil)
+
+	// 3、导出到文件，失败时删除不完整的压缩包
+	outFile, err := os.Create(zipPath)
+	if err != nil {
+		return err
+	}
+	report, err := exportSvc.Export(ctx, outFile, id)
+	if cerr := outFile.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(zipPath)
+		return err
+	}
+
+	// 4、输出导出报告
+	for _, url := range report.Missing {
+		fmt.Printf("MISSING %s\n", url)
+	}
+	fmt.Printf("Exported %d articles and %d images to %s, %d images kept as links\n",
+		report.Articles, report.Images, zipPath, len(report.Missing))
+	return nil
+}
@@ -0,0 +1,466 @@
+package flag
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"personal_blog/global"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/dto/request"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/adapter"
+	"personal_blog/internal/service/system"
+	"personal_blog/pkg/articleUtils"
+	"personal_blog/pkg/markdown"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// mdAbstractLength 文章未写摘要时，从正文截取的摘要长度（字符数）
+const mdAbstractLength = 200
+
+// mdDateLayouts 前言中日期字段支持的格式（不带时区的按本地时间解析）
+var mdDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+}
+
+// mdPost 从 Markdown 文件解析出的文章
+type mdPost struct {
+	Path     string
+	Title    string
+	Date     time.Time
+	Updated  time.Time
+	Category string
+	Tags     []string
+	Cover    string
+	Abstract string
+	Content  string
+	Draft    bool
+	Private  bool      // 仅我可见
+	Publish  time.Time // 前言中的 publish_at，晚于当前时间时导入为定时发布
+	Warnings []string
+}
+
+// MarkdownImport 导入目录下的 Markdown 文章（Hexo/Hugo 格式，支持 YAML/TOML 前言）
+// - 每篇文章都通过 ArticleSvc.ArticleImport 创建，与后台新建文章走同一流程，并保留原文的发布与更新时间
+// - 已存在同名标题的文章会跳过，重复执行不会产生重复文章
+// - author 为作者的用户名或邮箱，为空时使用 website.email 对应的用户
+// - dryRun 为 true 时只输出解析报告，不写入任何数据
+func MarkdownImport(dir string, author string, dryRun bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	// 1、收集并解析全部 Markdown 文件
+	paths, err := collectMarkdownFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no markdown files found in %s", dir)
+	}
+
+	// 2、初始化服务并确定作者（命令行标志在 Repository 层初始化之前执行，这里单独初始化）
+	mysqlAdapter := &adapter.MySQLAdapter{}
+	mysqlAdapter.SetConnection(global.DB)
+	repository.InitRepositoryGroupWithAdapter(mysqlAdapter)
+	user, err := resolveImportAuthor(ctx, author)
+	if err != nil {
+		return err
+	}
+	// 导入只创建文章，不涉及权限校验，无需注入权限服务
+	esOutboxSvc := system.NewEsOutboxSvc(repository.GroupApp, nil)
+	articleSvc := system.NewArticleSvc(repository.GroupApp, nil, esOutboxSvc)
+
+	// 3、逐篇导入，单篇失败不影响其余文章
+	var imported, skipped, failed int
+	seen := make(map[string]string) // 本次已处理的标题 -> 文件路径
+	for _, path := range paths {
+		post, err := parseMarkdownPost(path)
+		if err != nil {
+			failed++
+			fmt.Printf("FAIL  %s: %v\n", path, err)
+			continue
+		}
+		if first, ok := seen[post.Title]; ok {
+			skipped++
+			fmt.Printf("SKIP  %s: title %q duplicates %s\n", path, post.Title, first)
+			continue
+		}
+		seen[post.Title] = path
+		exists, err := articleUtils.Exists(ctx, post.Title)
+		if err != nil {
+			failed++
+			fmt.Printf("FAIL  %s: check title: %v\n", path, err)
+			continue
+		}
+		if exists {
+			skipped++
+			fmt.Printf("SKIP  %s: article %q already exists\n", path, post.Title)
+			continue
+		}
+		if dryRun {
+			imported++
+			printMarkdownPost("OK   ", post)
+			continue
+		}
+		id, err := articleSvc.ArticleImport(ctx, user.ID, user.UUID, post.createReq(), post.Date, post.Updated)
+		if err != nil {
+			failed++
+			fmt.Printf("FAIL  %s: %v\n", path, err)
+			continue
+		}
+		imported++
+		printMarkdownPost("DONE ", post)
+		fmt.Printf("      id=%s\n", id)
+	}
+
+	// 4、汇总
+	if dryRun {
+		fmt.Printf("Dry run: %d files, %d would be imported, %d skipped, %d failed. Nothing was written.\n",
+			len(paths), imported, skipped, failed)
+	} else {
+		fmt.Printf("Imported %d of %d files as %s, %d skipped, %d failed.\n",
+			imported, len(paths), user.Username, skipped, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d markdown files failed to import", failed)
+	}
+	return nil
+}
+
+// createReq 转换为创建文章请求：草稿保持草稿，publish_at 晚于当前时间的定时发布，其余按原发布时间发布
+// - 发布时间按本地时间格式化：不带时区的日期解析时已是本地时间，不会偏移；带时区的日期换算为同一时刻的本地时间
+func (p *mdPost) createReq() *request.ArticleCreateReq {
+	req := &request.ArticleCreateReq{
+		Cover:        p.Cover,
+		Title:        p.Title,
+		Category:     p.Category,
+		Tags:         p.Tags,
+		Abstract:     p.Abstract,
+		Content:      p.Content,
+		VisibleRange: esModel.VisiblePublic,
+		Status:       consts.ArticlePublished,
+		PublishAt:    p.Date.Local().Format("2006-01-02 15:04:05"),
+	}
+	if p.Private {
+		req.VisibleRange = esModel.VisiblePrivate
+	}
+	if !p.Publish.IsZero() {
+		req.PublishAt = p.Publish.Local().Format("2006-01-02 15:04:05")
+		if p.Publish.After(time.Now()) {
+			req.Status = consts.ArticleScheduled
+		}
+	}
+	if p.Draft {
+		req.Status, req.PublishAt = consts.ArticleDraft, ""
+	}
+	return req
+}
+
+// printMarkdownPost 输出单篇文章的解析结果
+func printMarkdownPost(prefix string, p *mdPost) {
+	status := "published"
+	switch {
+	case p.Draft:
+		status = "draft"
+	case p.Publish.After(time.Now()):
+		status = "scheduled at " + p.Publish.Local().Format("2006-01-02 15:04:05")
+	}
+	if p.Private {
+		status += " private"
+	}
+	fmt.Printf("%s %s: %q %s date=%s category=%q tags=%v\n",
+		prefix, p.Path, p.Title, status, p.Date.Local().Format("2006-01-02 15:04:05"), p.Category, p.Tags)
+	for _, w := range p.Warnings {
+		fmt.Printf("      warning: %s\n", w)
+	}
+}
+
+// resolveImportAuthor 按用户名或邮箱查找作者
+func resolveImportAuthor(ctx context.Context, author string) (*entity.User, error) {
+	author = strings.TrimSpace(author)
+	if author == "" {
+		author = global.Config.Website.Email
+	}
+	if author == "" {
+		return nil, errors.New("no author specified, use --author with a username or email")
+	}
+	userRepo := repository.GroupApp.SystemRepositorySupplier.GetUserRepository()
+	var user *entity.User
+	var err error
+	if strings.Contains(author, "@") {
+		user, err = userRepo.GetByEmail(ctx, author)
+	} else {
+		user, err = userRepo.GetByUsername(ctx, author)
+	}
+	if err != nil || user == nil {
+		return nil, fmt.Errorf("author %q not found: %v", author, err)
+	}
+	return user, nil
+}
+
+// collectMarkdownFiles 递归收集目录下的 .md/.markdown 文件（按路径排序）
+func collectMarkdownFiles(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".md", ".markdown":
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// parseMarkdownPost 解析单个 Markdown 文件
+// - 前言字段兼容 Hexo 与 Hugo：title、date、updated/lastmod、tags、categories、cover、description
+// - 缺失的字段按以下规则补齐：标题取文件名，日期取文件修改时间，摘要取正文开头
+// - 前言中 draft: true（或 Hexo 的 published: false）、以及 Hexo _drafts 目录下的文章导入为草稿
+// - 兼容 --md-export 导出的 visibility（public/private）与定时发布的 publish_at
+func parseMarkdownPost(path string) (*mdPost, error) {
+	// 1、读取并拆分前言与正文
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	meta, body, err := splitFrontMatter(data)
+	if err != nil {
+		return nil, err
+	}
+	post := &mdPost{Path: path, Content: strings.TrimSpace(body)}
+
+	// 2、标题
+	post.Title = stringField(meta, "title")
+	if post.Title == "" {
+		post.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		post.Warnings = append(post.Warnings, "no title, using file name")
+	}
+
+	// 3、发布与更新时间
+	var ok bool
+	if post.Date, ok = timeField(meta, "date"); !ok {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		post.Date = info.ModTime()
+		post.Warnings = append(post.Warnings, "no date, using file modification time")
+	}
+	if post.Updated, ok = timeField(meta, "updated", "lastmod"); !ok {
+		post.Updated = post.Date
+	}
+
+	// 4、分类与标签：只支持单个分类，多个分类（或 Hexo 的多级分类）取第一个
+	categories := listField(meta, "categories", "category")
+	if len(categories) > 0 {
+		post.Category = categories[0]
+		if len(categories) > 1 {
+			post.Warnings = append(post.Warnings, fmt.Sprintf("multiple categories %v, using %q", categories, post.Category))
+		}
+	} else {
+		post.Warnings = append(post.Warnings, "no category")
+	}
+	post.Tags = listField(meta, "tags", "tag")
+
+	// 5、封面与摘要
+	post.Cover = coverField(meta)
+	post.Abstract = stringField(meta, "description", "excerpt", "summary")
+	if post.Abstract == "" {
+		post.Abstract = markdown.Excerpt(moreExcerpt(post.Content), mdAbstractLength)
+	}
+
+	// 6、草稿
+	post.Draft = boolField(meta, "draft") || meta["published"] == false
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if dir == "_drafts" {
+			post.Draft = true
+		}
+	}
+
+	// 7、可见范围与定时发布
+	switch visibility := strings.ToLower(stringField(meta, "visibility")); visibility {
+	case "", "public":
+	case "private":
+		post.Private = true
+	default:
+		post.Warnings = append(post.Warnings, fmt.Sprintf("unknown visibility %q, using public", visibility))
+	}
+	if publish, ok := timeField(meta, "publish_at"); ok {
+		post.Publish = publish
+	} else if meta["publish_at"] != nil {
+		post.Warnings = append(post.Warnings, fmt.Sprintf("invalid publish_at %v, ignored", meta["publish_at"]))
+	}
+	return post, nil
+}
+
+// splitFrontMatter 拆分前言与正文：--- 包裹的为 YAML，+++ 包裹的为 TOML，没有前言时返回空字段
+func splitFrontMatter(data []byte) (map[string]any, string, error) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))), "\r\n", "\n")
+	meta := map[string]any{}
+	for _, delim := range []string{"---", "+++"} {
+		if !strings.HasPrefix(text, delim+"\n") {
+			continue
+		}
+		// 前言为空时（如 Hugo 的 ---\n---\n）剩余内容直接以结束分隔符开头
+		rest := "\n" + text[len(delim)+1:]
+		end := strings.Index(rest, "\n"+delim)
+		if end < 0 {
+			return nil, "", fmt.Errorf("front matter is not closed with %s", delim)
+		}
+		raw, body := rest[:end], rest[end+len(delim)+1:]
+		var err error
+		if delim == "---" {
+			meta, err = parseYAMLFrontMatter([]byte(raw))
+		} else {
+			err = toml.Unmarshal([]byte(raw), &meta)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("parse front matter: %v", err)
+		}
+		return meta, body, nil
+	}
+	return meta, text, nil
+}
+
+// parseYAMLFrontMatter 解析 YAML 前言
+// yaml.v3 会把不带时区的时间戳（如 date: 2020-01-01 12:00:00）按 UTC 解析，这里按本地时间重建，与字符串日期、TOML 本地日期保持一致
+func parseYAMLFrontMatter(raw []byte) (map[string]any, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	meta := map[string]any{}
+	if len(doc.Content) == 0 {
+		return meta, nil
+	}
+	root := doc.Content[0]
+	if err := root.Decode(&meta); err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		t, ok := meta[key].(time.Time)
+		if !ok || value.Kind != yaml.ScalarNode || yamlTimestampHasZone(value.Value) {
+			continue
+		}
+		meta[key] = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+	}
+	return meta, nil
+}
+
+// yamlTimestampHasZone 判断 YAML 时间戳是否写明了时区：日期之后出现 Z 或 +/- 偏移即视为带时区
+func yamlTimestampHasZone(value string) bool {
+	if i := strings.IndexAny(value, "Tt "); i >= 0 {
+		return strings.ContainsAny(value[i+1:], "Zz+-")
+	}
+	return false
+}
+
+// moreExcerpt Hexo/Hugo 用 <!-- more --> 标记摘要结束位置，存在时只取其之前的内容
+func moreExcerpt(content string) string {
+	for _, mark := range []string{"<!-- more -->", "<!--more-->"} {
+		if i := strings.Index(content, mark); i >= 0 {
+			return content[:i]
+		}
+	}
+	return content
+}
+
+// stringField 取第一个非空的字符串字段
+func stringField(meta map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := meta[key].(string); ok && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// boolField 取布尔字段，缺失时为 false
+func boolField(meta map[string]any, key string) bool {
+	v, _ := meta[key].(bool)
+	return v
+}
+
+// listField 取第一个非空的列表字段，单个字符串视为只有一个元素，嵌套列表（Hexo 多级分类）按顺序展开
+func listField(meta map[string]any, keys ...string) []string {
+	for _, key := range keys {
+		var list []string
+		var flatten func(v any)
+		flatten = func(v any) {
+			switch value := v.(type) {
+			case string:
+				if s := strings.TrimSpace(value); s != "" {
+					list = append(list, s)
+				}
+			case []any:
+				for _, item := range value {
+					flatten(item)
+				}
+			}
+		}
+		flatten(meta[key])
+		if len(list) > 0 {
+			return list
+		}
+	}
+	return nil
+}
+
+// timeField 取第一个可解析的时间字段，兼容字符串与 YAML/TOML 原生日期
+// - 不带时区的日期均为本地时间，带时区的日期保留原时区，由调用方按需转换为本地时间
+func timeField(meta map[string]any, keys ...string) (time.Time, bool) {
+	for _, key := range keys {
+		switch value := meta[key].(type) {
+		case time.Time:
+			return value, true
+		case toml.LocalDateTime:
+			return value.AsTime(time.Local), true
+		case toml.LocalDate:
+			return value.AsTime(time.Local), true
+		case string:
+			for _, layout := range mdDateLayouts {
+				if t, err := time.ParseInLocation(layout, strings.TrimSpace(value), time.Local); err == nil {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// coverField 取封面：cover 可以是地址，也可以是带 image 字段的对象（Hugo PaperMod）；兼容 thumbnail、image、banner
+func coverField(meta map[string]any) string {
+	if cover, ok := meta["cover"].(map[string]any); ok {
+		return stringField(cover, "image")
+	}
+	return stringField(meta, "cover", "thumbnail", "image", "banner")
+}
@@ -0,0 +1,282 @@
+package flag
+
+import (
+	"personal_blog/internal/model/consts"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// withLocal 测试期间把本地时区固定为东八区，避免运行环境为 UTC 时掩盖时区偏移问题
+func withLocal(t *testing.T) *time.Location {
+	t.Helper()
+	old := time.Local
+	time.Local = time.FixedZone("CST", 8*3600)
+	t.Cleanup(func() { time.Local = old })
+	return time.Local
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		meta    map[string]any
+		body    string
+		wantErr bool
+	}{
+		{
+			name: "没有前言",
+			data: "# hello\n",
+			meta: map[string]any{},
+			body: "# hello\n",
+		},
+		{
+			name: "YAML 前言",
+			data: "---\ntitle: Hello\ntags: [go, es]\n---\nbody\n",
+			meta: map[string]any{"title": "Hello", "tags": []any{"go", "es"}},
+			body: "\nbody\n",
+		},
+		{
+			name: "TOML 前言",
+			data: "+++\ntitle = \"Hello\"\ndraft = true\n+++\nbody",
+			meta: map[string]any{"title": "Hello", "draft": true},
+			body: "\nbody",
+		},
+		{
+			name: "去除 BOM 并兼容 CRLF",
+			data: "\xef\xbb\xbf---\r\ntitle: Hello\r\n---\r\nbody",
+			meta: map[string]any{"title": "Hello"},
+			body: "\nbody",
+		},
+		{
+			name: "空前言",
+			data: "---\n\n---\nbody",
+			meta: map[string]any{},
+			body: "\nbody",
+		},
+		{
+			name: "空前言紧跟结束分隔符",
+			data: "---\n---\nbody",
+			meta: map[string]any{},
+			body: "\nbody",
+		},
+		{
+			name: "空 TOML 前言",
+			data: "+++\n+++\n",
+			meta: map[string]any{},
+			body: "\n",
+		},
+		{
+			name:    "前言未闭合",
+			data:    "---\ntitle: Hello\nbody",
+			wantErr: true,
+		},
+		{
+			name:    "前言格式错误",
+			data:    "---\ntitle: [Hello\n---\nbody",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, body, err := splitFrontMatter([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitFrontMatter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(meta, tt.meta) {
+				t.Errorf("前言 = %#v, 期望 %#v", meta, tt.meta)
+			}
+			if body != tt.body {
+				t.Errorf("正文 = %q, 期望 %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestFrontMatterTime(t *testing.T) {
+	local := withLocal(t)
+	tests := []struct {
+		name string
+		data string
+		want time.Time
+	}{
+		{
+			name: "YAML 不带时区的时间戳按本地时间",
+			data: "---\ndate: 2020-01-01 12:00:00\n---\n",
+			want: time.Date(2020, 1, 1, 12, 0, 0, 0, local),
+		},
+		{
+			name: "YAML 只有日期按本地时间",
+			data: "---\ndate: 2020-01-01\n---\n",
+			want: time.Date(2020, 1, 1, 0, 0, 0, 0, local),
+		},
+		{
+			name: "YAML 带时区的时间戳保留时刻",
+			data: "---\ndate: 2020-01-01T12:00:00Z\n---\n",
+			want: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "YAML 带偏移的时间戳保留时刻",
+			data: "---\ndate: 2020-01-01T12:00:00-05:00\n---\n",
+			want: time.Date(2020, 1, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "YAML 字符串日期按本地时间",
+			data: "---\ndate: \"2020/01/01 12:00\"\n---\n",
+			want: time.Date(2020, 1, 1, 12, 0, 0, 0, local),
+		},
+		{
+			name: "TOML 本地日期时间",
+			data: "+++\ndate = 2020-01-01T12:00:00\n+++\n",
+			want: time.Date(2020, 1, 1, 12, 0, 0, 0, local),
+		},
+		{
+			name: "TOML 本地日期",
+			data: "+++\ndate = 2020-01-01\n+++\n",
+			want: time.Date(2020, 1, 1, 0, 0, 0, 0, local),
+		},
+		{
+			name: "TOML 带偏移的日期时间",
+			data: "+++\ndate = 2020-01-01T12:00:00+08:00\n+++\n",
+			want: time.Date(2020, 1, 1, 4, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, _, err := splitFrontMatter([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("splitFrontMatter() error = %v", err)
+			}
+			got, ok := timeField(meta, "date")
+			if !ok {
+				t.Fatalf("timeField() 未解析出时间: %#v", meta["date"])
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("timeField() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeField(t *testing.T) {
+	local := withLocal(t)
+	tests := []struct {
+		name   string
+		meta   map[string]any
+		keys   []string
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name:   "RFC3339 字符串",
+			meta:   map[string]any{"date": "2020-01-01T12:00:00+00:00"},
+			keys:   []string{"date"},
+			want:   time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+			wantOK: true,
+		},
+		{
+			name:   "不带时区的字符串按本地时间",
+			meta:   map[string]any{"date": " 2020-01-01 12:00:00 "},
+			keys:   []string{"date"},
+			want:   time.Date(2020, 1, 1, 12, 0, 0, 0, local),
+			wantOK: true,
+		},
+		{
+			name:   "取第一个可解析的字段",
+			meta:   map[string]any{"updated": "yesterday", "lastmod": "2020/01/02"},
+			keys:   []string{"updated", "lastmod"},
+			want:   time.Date(2020, 1, 2, 0, 0, 0, 0, local),
+			wantOK: true,
+		},
+		{
+			name: "无法解析",
+			meta: map[string]any{"date": "yesterday", "count": 3},
+			keys: []string{"date", "count", "missing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := timeField(tt.meta, tt.keys...)
+			if ok != tt.wantOK {
+				t.Fatalf("timeField() ok = %v, 期望 %v", ok, tt.wantOK)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("timeField() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateReqPublishAt(t *testing.T) {
+	withLocal(t)
+	meta, _, err := splitFrontMatter([]byte("---\ndate: 2020-01-01 12:00:00\n---\n"))
+	if err != nil {
+		t.Fatalf("splitFrontMatter() error = %v", err)
+	}
+	date, _ := timeField(meta, "date")
+	post := &mdPost{Title: "Hello", Date: date}
+	if got := post.createReq().PublishAt; got != "2020-01-01 12:00:00" {
+		t.Errorf("PublishAt = %q, 期望 %q", got, "2020-01-01 12:00:00")
+	}
+}
+
+func TestCreateReqStatus(t *testing.T) {
+	local := withLocal(t)
+	date := time.Date(2020, 1, 1, 12, 0, 0, 0, local)
+	future := time.Now().Add(24 * time.Hour).In(local)
+	tests := []struct {
+		name       string
+		post       mdPost
+		status     consts.ArticleStatus
+		publishAt  string
+		visibility uint
+	}{
+		{
+			name:       "已发布",
+			post:       mdPost{Date: date},
+			status:     consts.ArticlePublished,
+			publishAt:  "2020-01-01 12:00:00",
+			visibility: esModel.VisiblePublic,
+		},
+		{
+			name:       "草稿",
+			post:       mdPost{Date: date, Draft: true, Publish: future},
+			status:     consts.ArticleDraft,
+			visibility: esModel.VisiblePublic,
+		},
+		{
+			name:       "仅我可见",
+			post:       mdPost{Date: date, Private: true},
+			status:     consts.ArticlePublished,
+			publishAt:  "2020-01-01 12:00:00",
+			visibility: esModel.VisiblePrivate,
+		},
+		{
+			name:       "定时发布",
+			post:       mdPost{Date: date, Publish: future},
+			status:     consts.ArticleScheduled,
+			publishAt:  future.Format("2006-01-02 15:04:05"),
+			visibility: esModel.VisiblePublic,
+		},
+		{
+			name:       "定时发布时间已过按该时间发布",
+			post:       mdPost{Date: date, Publish: date.Add(time.Hour)},
+			status:     consts.ArticlePublished,
+			publishAt:  "2020-01-01 13:00:00",
+			visibility: esModel.VisiblePublic,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.post.createReq()
+			if req.Status != tt.status || req.PublishAt != tt.publishAt || req.VisibleRange != tt.visibility {
+				t.Errorf("createReq() = {Status: %d, PublishAt: %q, VisibleRange: %d}, 期望 {%d, %q, %d}",
+					req.Status, req.PublishAt, req.VisibleRange, tt.status, tt.publishAt, tt.visibility)
+			}
+		})
+	}
+}
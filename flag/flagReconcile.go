@@ -0,0 +1,55 @@
+package flag
+
+import (
+	"context"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/adapter"
+	"personal_blog/internal/service/system"
+	"time"
+)
+
+// Reconcile 核对分类/标签计数：mode 为 check 时只报告偏差，为 repair 时同时修复 MySQL 中的计数
+func Reconcile(mode string) error {
+	var repair bool
+	switch mode {
+	case "check":
+	case "repair":
+		repair = true
+	default:
+		return fmt.Errorf("invalid reconcile mode %q, expected 'check' or 'repair'", mode)
+	}
+
+	// 命令行标志在 Repository 层初始化之前执行，这里单独初始化
+	mysqlAdapter := &adapter.MySQLAdapter{}
+	mysqlAdapter.SetConnection(global.DB)
+	repository.InitRepositoryGroupWithAdapter(mysqlAdapter)
+	// 对账不涉及权限校验，也不改写文章，无需注入权限服务与发件箱服务
+	taxonomySvc := system.NewArticleTaxonomySvc(repository.GroupApp, nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	res, err := taxonomySvc.Reconcile(ctx, repair)
+	if err != nil {
+		return err
+	}
+
+	// 打印对账结果
+	if len(res.Categories) == 0 && len(res.Tags) == 0 {
+		fmt.Println("Category and tag counts are consistent with Elasticsearch.")
+		return nil
+	}
+	for _, d := range res.Categories {
+		fmt.Printf("category %q: mysql=%d es=%d\n", d.Name, d.Actual, d.Expected)
+	}
+	for _, d := range res.Tags {
+		fmt.Printf("tag %q: mysql=%d es=%d\n", d.Name, d.Actual, d.Expected)
+	}
+	if res.Repaired {
+		fmt.Printf("Repaired %d categories and %d tags.\n", len(res.Categories), len(res.Tags))
+	} else {
+		fmt.Println("Run with --reconcile repair to fix the counts.")
+	}
+	return nil
+}
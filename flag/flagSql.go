@@ -23,5 +23,11 @@ func SQL() error {
 		&entity.ArticleCategory{}, // 文章类别表
 		&entity.ArticleTag{},      // 文章标签表
 		&entity.ArticleLike{},     // 文章点赞表
+		&entity.Comment{},         // 文章评论表
+		&entity.ArticleRevision{}, // 文章历史版本表
+		&entity.EsOutbox{},        // ES 同步发件箱表
+		// 文章专栏
+		&entity.ArticleSeries{},     // 文章专栏表
+		&entity.ArticleSeriesItem{}, // 专栏文章表（文章顺序）
 	)
 }
@@ -1,9 +1,11 @@
 package system
 
 import (
+	"errors"
 	"personal_blog/global"
 	"personal_blog/internal/model/dto/request"
 	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
 	"personal_blog/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -12,11 +14,19 @@ import (
 
 // ArticleCtrl 文章控制器
 type ArticleCtrl struct {
-	articleSvc *serviceSystem.ArticleSvc
+	articleSvc     *serviceSystem.ArticleSvc
+	articleViewSvc *serviceSystem.ArticleViewSvc
 }
 
 // CreateArticle 创建文章
 func (a *ArticleCtrl) CreateArticle(ctx *gin.Context) {
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
 	var req request.ArticleCreateReq
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
@@ -26,7 +36,7 @@ func (a *ArticleCtrl) CreateArticle(ctx *gin.Context) {
 			Failed("绑定数据错误", nil)
 		return
 	}
-	err = a.articleSvc.ArticleCreate(ctx, &req)
+	err = a.articleSvc.ArticleCreate(ctx, uid, jwt.GetUUID(ctx), &req)
 	if err != nil {
 		global.Log.Error("创建文章失败", zap.String("title", req.Title), zap.Error(err))
 		response.NewResponse[any, any](ctx).
@@ -47,7 +57,15 @@ func (a *ArticleCtrl) CreateArticle(ctx *gin.Context) {
 
 // DeleteArticle 删除文章
 func (a *ArticleCtrl) DeleteArticle(ctx *gin.Context) {
-	// 1、获取请求结构体
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
 	var req request.ArticleDeleteReq
 	err := ctx.ShouldBindJSON(&req)
 	if err != nil {
@@ -57,8 +75,20 @@ func (a *ArticleCtrl) DeleteArticle(ctx *gin.Context) {
 			Failed("绑定数据错误", nil)
 		return
 	}
-	// 2、删除文章
-	err = a.articleSvc.ArticleDelete(ctx, &req)
+	// 3、删除文章
+	err = a.articleSvc.ArticleDelete(ctx, uid, &req)
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if errors.Is(err, serviceSystem.ErrArticleSyncing) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
 	if err != nil {
 		global.Log.Error("删除文章失败", zap.Strings("ids", req.IDs), zap.Error(err))
 		response.NewResponse[any, any](ctx).
@@ -66,7 +96,7 @@ func (a *ArticleCtrl) DeleteArticle(ctx *gin.Context) {
 			Failed("删除文章失败", nil)
 		return
 	}
-	// 3、返回数据
+	// 4、返回数据
 	response.NewResponse[any, any](ctx).
 		SetCode(global.StatusOK).
 		Success("删除成功", map[string]any{
@@ -76,7 +106,15 @@ func (a *ArticleCtrl) DeleteArticle(ctx *gin.Context) {
 
 // ArticleUpdate 更新文章
 func (a *ArticleCtrl) ArticleUpdate(ctx *gin.Context) {
-	// 1、获取请求结构体
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
 	var req request.ArticleUpdateReq
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		global.Log.Error("绑定数据错误", zap.Error(err))
@@ -85,15 +123,28 @@ func (a *ArticleCtrl) ArticleUpdate(ctx *gin.Context) {
 			Failed("绑定数据错误", nil)
 		return
 	}
-	// 2、更新文章
-	if err := a.articleSvc.UpdateArticle(ctx.Request.Context(), req); err != nil {
+	// 3、更新文章（记录编辑者，用于历史版本）
+	err := a.articleSvc.UpdateArticle(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if errors.Is(err, serviceSystem.ErrArticleSyncing) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
 		global.Log.Error("更新文章失败", zap.String("id", req.ID), zap.Error(err))
 		response.NewResponse[any, any](ctx).
 			SetCode(global.StatusInternalServerError).
 			Failed("更新文章失败", nil)
 		return
 	}
-	// 3、返回数据
+	// 4、返回数据
 	response.NewResponse[any, any](ctx).
 		SetCode(global.StatusOK).
 		Success("更新成功", map[string]any{
@@ -102,6 +153,56 @@ func (a *ArticleCtrl) ArticleUpdate(ctx *gin.Context) {
 		})
 }
 
+// ArticlePromote 设置文章置顶与精选（仅管理员）
+func (a *ArticleCtrl) ArticlePromote(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticlePromoteReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、设置置顶与精选
+	err := a.articleSvc.PromoteArticle(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if errors.Is(err, serviceSystem.ErrPromotionInvalid) || errors.Is(err, serviceSystem.ErrArticleSyncing) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("设置文章置顶/精选失败", zap.String("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("设置文章置顶/精选失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("设置成功", map[string]any{
+			"id":       req.ID,
+			"pinned":   req.Pinned,
+			"featured": req.Featured,
+		})
+}
+
 // ArticleList 获取文章列表
 func (a *ArticleCtrl) ArticleList(ctx *gin.Context) {
 	// 1、获取请求结构体
@@ -114,7 +215,13 @@ func (a *ArticleCtrl) ArticleList(ctx *gin.Context) {
 		return
 	}
 	// 2、获取数据
-	respData, err := a.articleSvc.GetArticleList(ctx.Request.Context(), req)
+	respData, err := a.articleSvc.GetArticleList(ctx.Request.Context(), jwt.GetUserID(ctx), req)
+	if errors.Is(err, serviceSystem.ErrCursorInvalid) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
 	if err != nil {
 		global.Log.Error("获取文章列表失败", zap.Error(err))
 		response.NewResponse[any, any](ctx).
@@ -122,6 +229,114 @@ func (a *ArticleCtrl) ArticleList(ctx *gin.Context) {
 			Failed("获取文章列表失败", nil)
 		return
 	}
+	// 3、按ID查看文章详情时记录浏览量（失败不影响正常返回）
+	if req.ID != nil && respData.Total > 0 {
+		visitor := a.articleViewSvc.ViewVisitor(jwt.GetUserID(ctx), ctx.ClientIP(), ctx.Request.UserAgent())
+		if err = a.articleViewSvc.RecordView(ctx.Request.Context(), *req.ID, visitor); err != nil {
+			global.Log.Warn("记录文章浏览失败", zap.String("id", *req.ID), zap.Error(err))
+		}
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleSearch 关键字全文搜索
+func (a *ArticleCtrl) ArticleSearch(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSearchReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、搜索
+	respData, err := a.articleSvc.SearchArticles(ctx.Request.Context(), jwt.GetUserID(ctx), req)
+	if errors.Is(err, serviceSystem.ErrCursorInvalid) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("搜索文章失败", zap.String("keyword", req.Keyword), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("搜索文章失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleSuggest 搜索框标题联想
+func (a *ArticleCtrl) ArticleSuggest(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSuggestReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、联想
+	respData, err := a.articleSvc.SuggestTitles(ctx.Request.Context(), jwt.GetUserID(ctx), req)
+	if err != nil {
+		global.Log.Error("标题联想失败", zap.String("keyword", req.Keyword), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("标题联想失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleArchive 文章归档（年 -> 月 -> 文章）
+func (a *ArticleCtrl) ArticleArchive(ctx *gin.Context) {
+	// 1、获取数据
+	respData, err := a.articleSvc.Archive(ctx.Request.Context(), jwt.GetUserID(ctx))
+	if err != nil {
+		global.Log.Error("获取文章归档失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取文章归档失败", nil)
+		return
+	}
+	// 2、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleArchiveMonth 按月查看归档（分页）
+func (a *ArticleCtrl) ArticleArchiveMonth(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleArchiveMonthReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := a.articleSvc.ArchiveMonth(ctx.Request.Context(), jwt.GetUserID(ctx), req)
+	if err != nil {
+		global.Log.Error("获取月度归档失败", zap.Int("year", req.Year), zap.Int("month", req.Month), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取月度归档失败", nil)
+		return
+	}
 	// 3、返回数据
 	response.NewResponse[any, any](ctx).
 		SetCode(global.StatusOK).
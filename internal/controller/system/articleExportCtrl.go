@@ -0,0 +1,98 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// articleExportTimeout 导出文章的超时时间，导出接口不受全局请求超时限制
+const articleExportTimeout = 30 * time.Minute
+
+// ArticleExportCtrl 文章导出控制器
+type ArticleExportCtrl struct {
+	articleExportSvc *serviceSystem.ArticleExportSvc
+}
+
+// ArticleExport 将文章导出为 Markdown 压缩包下载
+// - 传 id 时导出单篇（仅作者或管理员），不传时导出当前用户有权导出的全部文章
+// - 压缩包边生成边写入响应，不在服务端缓存；生成中途失败时断开连接
+// - 未能打包的图片记录在压缩包内的导出报告（export-report.json）中
+func (a *ArticleExportCtrl) ArticleExport(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleExportReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、流式写入响应：首次写入时才发送响应头，写入前出错（如无权导出）仍能返回 JSON 错误
+	name := "articles_" + time.Now().Format("20060102") + ".zip"
+	if req.ID != "" {
+		name = "article_" + req.ID + ".zip"
+	}
+	exportCtx, cancel := context.WithTimeout(ctx.Request.Context(), articleExportTimeout)
+	defer cancel()
+	w := &attachmentWriter{ctx: ctx, name: name}
+	report, err := a.articleExportSvc.ExportFor(exportCtx, uid, req.ID, w)
+	if err != nil && w.started {
+		// 响应头已发出，无法再返回错误，直接断开连接，让客户端感知下载失败而不是得到一个不完整的压缩包
+		global.Log.Error("导出文章中断", zap.String("id", req.ID), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("导出文章失败", zap.String("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("导出文章失败", nil)
+		return
+	}
+	global.Log.Info("导出文章",
+		zap.Uint("userID", uid),
+		zap.Int("articles", report.Articles),
+		zap.Int("images", report.Images),
+		zap.Int("missing", len(report.Missing)))
+}
+
+// attachmentWriter 以附件形式写入响应，首次写入时发送响应头
+type attachmentWriter struct {
+	ctx     *gin.Context
+	name    string
+	started bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.ctx.Header("Content-Type", "application/zip")
+		w.ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": w.name}))
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(p)
+}
@@ -0,0 +1,137 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ArticleLikeCtrl 文章收藏控制器
+type ArticleLikeCtrl struct {
+	articleLikeSvc *serviceSystem.ArticleLikeSvc
+}
+
+// ToggleLike 收藏/取消收藏
+func (a *ArticleLikeCtrl) ToggleLike(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleLikeToggleReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、切换收藏状态
+	respData, err := a.articleLikeSvc.ToggleLike(ctx.Request.Context(), uid, &req)
+	if errors.Is(err, serviceSystem.ErrArticleNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(serviceSystem.ErrArticleNotFound.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("收藏操作失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed(fmt.Sprintf("收藏操作失败: %v", err), nil)
+		return
+	}
+	// 4、返回数据
+	msg := "已取消收藏"
+	if respData.Liked {
+		msg = "收藏成功"
+	}
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success(msg, respData)
+}
+
+// LikeStatus 查询是否已收藏
+func (a *ArticleLikeCtrl) LikeStatus(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleLikeStatusReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、获取数据
+	respData, err := a.articleLikeSvc.IsLiked(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrArticleNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(serviceSystem.ErrArticleNotFound.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("查询收藏状态失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("查询收藏状态失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// LikedList 我收藏的文章
+func (a *ArticleLikeCtrl) LikedList(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.PageInfo
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、获取数据
+	respData, err := a.articleLikeSvc.LikedArticles(ctx.Request.Context(), uid, req)
+	if err != nil {
+		global.Log.Error("获取收藏列表失败", zap.Uint("user_id", uid), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取收藏列表失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
@@ -0,0 +1,149 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ArticleRevisionCtrl 文章历史版本控制器
+type ArticleRevisionCtrl struct {
+	articleRevisionSvc *serviceSystem.ArticleRevisionSvc
+}
+
+// RevisionList 获取文章历史版本列表
+func (a *ArticleRevisionCtrl) RevisionList(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleRevisionListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、获取数据
+	respData, err := a.articleRevisionSvc.GetRevisionList(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取历史版本列表失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取历史版本列表失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// RevisionDiff 对比两个历史版本
+func (a *ArticleRevisionCtrl) RevisionDiff(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleRevisionDiffReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、对比版本
+	respData, err := a.articleRevisionSvc.DiffRevisions(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("对比历史版本失败",
+			zap.Uint("from", req.From), zap.Uint("to", req.To), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(fmt.Sprintf("对比历史版本失败: %v", err), nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// RestoreRevision 恢复到指定历史版本
+func (a *ArticleRevisionCtrl) RestoreRevision(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleRevisionRestoreReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、恢复版本
+	revision, err := a.articleRevisionSvc.RestoreRevision(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrArticleForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if errors.Is(err, serviceSystem.ErrArticleSyncing) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("恢复历史版本失败", zap.Uint("revision_id", req.RevisionID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed(fmt.Sprintf("恢复历史版本失败: %v", err), nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("恢复成功", map[string]any{
+			"article_id": revision.ArticleID,
+			"version":    revision.Version,
+		})
+}
@@ -0,0 +1,237 @@
+package system
+
+import (
+	"errors"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ArticleSeriesCtrl 文章专栏控制器
+type ArticleSeriesCtrl struct {
+	articleSeriesSvc *serviceSystem.ArticleSeriesSvc
+}
+
+// CreateSeries 创建专栏
+func (a *ArticleSeriesCtrl) CreateSeries(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleSeriesCreateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、创建专栏
+	respData, err := a.articleSeriesSvc.CreateSeries(ctx.Request.Context(), uid, req)
+	if err != nil {
+		global.Log.Error("创建专栏失败", zap.String("title", req.Title), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("创建专栏失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("创建成功", respData)
+}
+
+// UpdateSeries 更新专栏的标题、简介与封面
+func (a *ArticleSeriesCtrl) UpdateSeries(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleSeriesUpdateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、更新专栏
+	err := a.articleSeriesSvc.UpdateSeries(ctx.Request.Context(), uid, req)
+	if seriesFailed(ctx, err) {
+		return
+	}
+	if err != nil {
+		global.Log.Error("更新专栏失败", zap.Uint("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("更新专栏失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("更新成功", nil)
+}
+
+// DeleteSeries 删除专栏（专栏中的文章保留）
+func (a *ArticleSeriesCtrl) DeleteSeries(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleSeriesDeleteReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、删除专栏
+	err := a.articleSeriesSvc.DeleteSeries(ctx.Request.Context(), uid, req)
+	if seriesFailed(ctx, err) {
+		return
+	}
+	if err != nil {
+		global.Log.Error("删除专栏失败", zap.Uint("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("删除专栏失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("删除成功", nil)
+}
+
+// SetSeriesArticles 设置专栏中的文章及其顺序（整体替换）
+func (a *ArticleSeriesCtrl) SetSeriesArticles(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.ArticleSeriesArticlesReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、设置文章顺序
+	err := a.articleSeriesSvc.SetSeriesArticles(ctx.Request.Context(), uid, req)
+	if seriesFailed(ctx, err) {
+		return
+	}
+	if err != nil {
+		global.Log.Error("设置专栏文章失败", zap.Uint("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("设置专栏文章失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("设置成功", nil)
+}
+
+// SeriesList 专栏列表
+func (a *ArticleSeriesCtrl) SeriesList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSeriesListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := a.articleSeriesSvc.SeriesList(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取专栏列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取专栏列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// SeriesDetail 专栏详情（作者可看到专栏中自己未发布的文章）
+func (a *ArticleSeriesCtrl) SeriesDetail(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSeriesDetailReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := a.articleSeriesSvc.SeriesDetail(ctx.Request.Context(), jwt.GetUserID(ctx), req)
+	if seriesFailed(ctx, err) {
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取专栏详情失败", zap.Uint("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取专栏详情失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// seriesFailed 处理专栏相关的业务错误（不存在、无权限、文章不合法），已处理时返回 true
+func seriesFailed(ctx *gin.Context, err error) bool {
+	var code global.AppCode
+	switch {
+	case errors.Is(err, serviceSystem.ErrSeriesForbidden),
+		errors.Is(err, serviceSystem.ErrArticleForbidden):
+		code = global.StatusForbidden
+	case errors.Is(err, serviceSystem.ErrSeriesNotFound),
+		errors.Is(err, serviceSystem.ErrSeriesArticleInvalid):
+		code = global.StatusBadRequest
+	default:
+		return false
+	}
+	response.NewResponse[any, any](ctx).
+		SetCode(code).
+		Failed(err.Error(), nil)
+	return true
+}
@@ -0,0 +1,163 @@
+package system
+
+import (
+	"errors"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ArticleTaxonomyCtrl 文章分类与标签管理控制器
+type ArticleTaxonomyCtrl struct {
+	articleTaxonomySvc *serviceSystem.ArticleTaxonomySvc
+}
+
+// CategoryList 分类列表（含文章数）
+func (a *ArticleTaxonomyCtrl) CategoryList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleTaxonomyListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := a.articleTaxonomySvc.CategoryList(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取分类列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取分类列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// CategoryRename 重命名分类（目标分类已存在时合并），仅管理员
+func (a *ArticleTaxonomyCtrl) CategoryRename(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.CategoryRenameReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、重命名分类
+	updated, err := a.articleTaxonomySvc.RenameCategory(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrTaxonomyForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if errors.Is(err, serviceSystem.ErrArticleSyncing) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("重命名分类失败", zap.String("from", req.From), zap.String("to", req.To), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("重命名分类失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("重命名分类成功", resp.TaxonomyUpdateResp{Updated: updated})
+}
+
+// TagList 标签列表（含文章数）
+func (a *ArticleTaxonomyCtrl) TagList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleTaxonomyListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := a.articleTaxonomySvc.TagList(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取标签列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取标签列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// TagMerge 合并标签，仅管理员
+func (a *ArticleTaxonomyCtrl) TagMerge(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.TagMergeReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、合并标签
+	updated, err := a.articleTaxonomySvc.MergeTags(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrTaxonomyForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if errors.Is(err, serviceSystem.ErrArticleSyncing) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("合并标签失败", zap.Strings("sources", req.Sources), zap.String("target", req.Target), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("合并标签失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("合并标签成功", resp.TaxonomyUpdateResp{Updated: updated})
+}
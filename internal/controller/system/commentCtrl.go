@@ -0,0 +1,131 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CommentCtrl 评论控制器
+type CommentCtrl struct {
+	commentSvc *serviceSystem.CommentSvc
+}
+
+// CreateComment 发表评论
+func (c *CommentCtrl) CreateComment(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.CommentCreateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、创建评论
+	comment, err := c.commentSvc.CommentCreate(ctx.Request.Context(), uid, &req)
+	if errors.Is(err, serviceSystem.ErrArticleNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(serviceSystem.ErrArticleNotFound.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("发表评论失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed(fmt.Sprintf("发表评论失败: %v", err), nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("评论成功", map[string]any{
+			"id":         comment.ID,
+			"article_id": comment.ArticleID,
+			"parent_id":  comment.ParentID,
+			"root_id":    comment.RootID,
+		})
+}
+
+// CommentList 获取文章评论列表
+func (c *CommentCtrl) CommentList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.CommentListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := c.commentSvc.GetCommentList(ctx.Request.Context(), jwt.GetUserID(ctx), req)
+	if errors.Is(err, serviceSystem.ErrArticleNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(serviceSystem.ErrArticleNotFound.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取评论列表失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取评论列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// DeleteComment 删除评论
+func (c *CommentCtrl) DeleteComment(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.CommentDeleteReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、删除评论
+	if err := c.commentSvc.CommentDelete(ctx.Request.Context(), uid, &req); err != nil {
+		global.Log.Error("删除评论失败", zap.Uint("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed(fmt.Sprintf("删除评论失败: %v", err), nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("删除成功", map[string]any{
+			"id": req.ID,
+		})
+}
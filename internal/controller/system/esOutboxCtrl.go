@@ -0,0 +1,100 @@
+package system
+
+import (
+	"errors"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/jwt"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// EsOutboxCtrl ES 同步发件箱控制器
+type EsOutboxCtrl struct {
+	esOutboxSvc *serviceSystem.EsOutboxSvc
+}
+
+// DeadList 查询同步失败且重试耗尽的 ES 操作
+func (e *EsOutboxCtrl) DeadList(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.EsOutboxDeadListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、获取数据
+	respData, err := e.esOutboxSvc.DeadList(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrEsOutboxForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取ES同步死信失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取ES同步死信失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// DeadRequeue 将死信重新放回待同步队列
+func (e *EsOutboxCtrl) DeadRequeue(ctx *gin.Context) {
+	// 1、获取当前用户
+	uid := jwt.GetUserID(ctx)
+	if uid == 0 {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusUnauthorized).
+			Failed("未认证或凭证无效", nil)
+		return
+	}
+	// 2、获取请求结构体
+	var req request.EsOutboxRequeueReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 3、重新入队
+	count, err := e.esOutboxSvc.Requeue(ctx.Request.Context(), uid, req)
+	if errors.Is(err, serviceSystem.ErrEsOutboxForbidden) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusForbidden).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("死信重新入队失败", zap.Uints("ids", req.IDs), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("死信重新入队失败", nil)
+		return
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("已重新入队", map[string]any{
+			"count": count,
+		})
+}
@@ -0,0 +1,59 @@
+package system
+
+import (
+	"net/http"
+	"personal_blog/global"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// FeedCtrl RSS/Atom 订阅源控制器（无需登录）
+type FeedCtrl struct {
+	feedSvc *serviceSystem.FeedSvc
+}
+
+// RSS RSS 2.0 订阅源（全站/分类/标签）
+func (f *FeedCtrl) RSS(ctx *gin.Context) {
+	f.feed(ctx, consts.FeedRSS)
+}
+
+// Atom Atom 1.0 订阅源（全站/分类/标签）
+func (f *FeedCtrl) Atom(ctx *gin.Context) {
+	f.feed(ctx, consts.FeedAtom)
+}
+
+// feed 生成并返回订阅源，内容未变化时返回 304
+func (f *FeedCtrl) feed(ctx *gin.Context, format consts.FeedFormat) {
+	// 1、获取请求结构体
+	var req request.FeedReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取订阅源
+	body, etag, err := f.feedSvc.Feed(ctx.Request.Context(), format, req)
+	if err != nil {
+		global.Log.Error("获取订阅源失败", zap.String("format", format.String()), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取订阅源失败", nil)
+		return
+	}
+	// 3、协商缓存：客户端持有的版本未变化时只返回 304
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", "public, max-age=300")
+	if ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	// 4、返回 XML
+	ctx.Data(http.StatusOK, format.ContentType(), body)
+}
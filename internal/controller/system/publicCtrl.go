@@ -0,0 +1,357 @@
+package system
+
+import (
+	"errors"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PublicCtrl 公开只读接口控制器（无需登录，按游客身份访问）
+type PublicCtrl struct {
+	articleSvc       *serviceSystem.ArticleSvc
+	articleViewSvc   *serviceSystem.ArticleViewSvc
+	articleSeriesSvc *serviceSystem.ArticleSeriesSvc
+}
+
+// ArticleList 公开文章列表
+func (p *PublicCtrl) ArticleList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticlePublicListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.PublicArticleList(ctx.Request.Context(), req)
+	if errors.Is(err, serviceSystem.ErrCursorInvalid) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取文章列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取文章列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleDetail 公开文章详情（含正文）
+func (p *PublicCtrl) ArticleDetail(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleDetailReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.PublicArticleDetail(ctx.Request.Context(), req.ID)
+	if errors.Is(err, serviceSystem.ErrArticleNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取文章详情失败", zap.String("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取文章详情失败", nil)
+		return
+	}
+	// 3、记录浏览量（游客按 IP + UA 去重，失败不影响正常返回）
+	visitor := p.articleViewSvc.ViewVisitor(0, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err = p.articleViewSvc.RecordView(ctx.Request.Context(), req.ID, visitor); err != nil {
+		global.Log.Warn("记录文章浏览失败", zap.String("id", req.ID), zap.Error(err))
+	}
+	// 4、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleSearch 公开关键字全文搜索
+func (p *PublicCtrl) ArticleSearch(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSearchReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、搜索
+	respData, err := p.articleSvc.PublicSearchArticles(ctx.Request.Context(), req)
+	if errors.Is(err, serviceSystem.ErrCursorInvalid) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("搜索文章失败", zap.String("keyword", req.Keyword), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("搜索文章失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleSuggest 公开标题联想
+func (p *PublicCtrl) ArticleSuggest(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSuggestReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、联想
+	respData, err := p.articleSvc.PublicSuggestTitles(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("标题联想失败", zap.String("keyword", req.Keyword), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("标题联想失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleRelated 相关文章推荐
+func (p *PublicCtrl) ArticleRelated(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleRelatedReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.RelatedArticles(ctx.Request.Context(), req)
+	if errors.Is(err, serviceSystem.ErrArticleNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取相关文章失败", zap.String("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取相关文章失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleCarousel 首页轮播（有封面的精选文章）
+func (p *PublicCtrl) ArticleCarousel(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleCarouselReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.Carousel(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取首页轮播失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取首页轮播失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleArchive 公开文章归档（年 -> 月 -> 文章）
+func (p *PublicCtrl) ArticleArchive(ctx *gin.Context) {
+	// 1、获取数据
+	respData, err := p.articleSvc.PublicArchive(ctx.Request.Context())
+	if err != nil {
+		global.Log.Error("获取文章归档失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取文章归档失败", nil)
+		return
+	}
+	// 2、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// ArticleArchiveMonth 公开按月查看归档（分页）
+func (p *PublicCtrl) ArticleArchiveMonth(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleArchiveMonthReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.PublicArchiveMonth(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取月度归档失败", zap.Int("year", req.Year), zap.Int("month", req.Month), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取月度归档失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// CategoryList 公开分类列表
+func (p *PublicCtrl) CategoryList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleTaxonomyListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.PublicCategoryList(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取分类列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取分类列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// TagList 公开标签列表
+func (p *PublicCtrl) TagList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleTaxonomyListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSvc.PublicTagList(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取标签列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取标签列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// SeriesList 公开专栏列表
+func (p *PublicCtrl) SeriesList(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSeriesListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSeriesSvc.SeriesList(ctx.Request.Context(), req)
+	if err != nil {
+		global.Log.Error("获取专栏列表失败", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取专栏列表失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
+
+// SeriesDetail 公开专栏详情（只含公开且已发布的文章）
+func (p *PublicCtrl) SeriesDetail(ctx *gin.Context) {
+	// 1、获取请求结构体
+	var req request.ArticleSeriesDetailReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	// 2、获取数据
+	respData, err := p.articleSeriesSvc.PublicSeriesDetail(ctx.Request.Context(), req)
+	if errors.Is(err, serviceSystem.ErrSeriesNotFound) {
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed(err.Error(), nil)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取专栏详情失败", zap.Uint("id", req.ID), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取专栏详情失败", nil)
+		return
+	}
+	// 3、返回数据
+	response.NewResponse[any, any](ctx).
+		SetCode(global.StatusOK).
+		Success("获取成功", respData)
+}
@@ -0,0 +1,75 @@
+package system
+
+import (
+	"errors"
+	"net/http"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	serviceSystem "personal_blog/internal/service/system"
+	"personal_blog/pkg/response"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SeoCtrl 站点地图与 robots.txt 控制器（无需登录）
+type SeoCtrl struct {
+	seoSvc *serviceSystem.SeoSvc
+}
+
+// Sitemap 站点地图
+func (s *SeoCtrl) Sitemap(ctx *gin.Context) {
+	s.sitemap(ctx, 0)
+}
+
+// SitemapPage 分页站点地图（地址数超过单个文件上限时由站点地图索引引用）
+func (s *SeoCtrl) SitemapPage(ctx *gin.Context) {
+	// 1、获取请求结构体，页码形如 1.xml
+	var req request.SitemapPageReq
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		global.Log.Error("绑定数据错误", zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusBadRequest).
+			Failed("绑定数据错误", nil)
+		return
+	}
+	page, err := strconv.Atoi(strings.TrimSuffix(req.Page, ".xml"))
+	if err != nil || page < 1 {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	// 2、返回分页
+	s.sitemap(ctx, page)
+}
+
+// Robots robots.txt
+func (s *SeoCtrl) Robots(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(s.seoSvc.Robots()))
+}
+
+// sitemap 生成并返回站点地图，内容未变化时返回 304
+func (s *SeoCtrl) sitemap(ctx *gin.Context, page int) {
+	// 1、获取站点地图
+	body, etag, err := s.seoSvc.Sitemap(ctx.Request.Context(), page)
+	if errors.Is(err, serviceSystem.ErrSitemapNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		global.Log.Error("获取站点地图失败", zap.Int("page", page), zap.Error(err))
+		response.NewResponse[any, any](ctx).
+			SetCode(global.StatusInternalServerError).
+			Failed("获取站点地图失败", nil)
+		return
+	}
+	// 2、协商缓存：客户端持有的版本未变化时只返回 304
+	ctx.Header("ETag", etag)
+	if ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	// 3、返回 XML
+	ctx.Data(http.StatusOK, "application/xml; charset=utf-8", body)
+}
@@ -10,6 +10,16 @@ type Supplier interface {
 	GetUserCtrl() *UserCtrl
 	GetImageCtrl() *ImageCtrl
 	GetArticleCtrl() *ArticleCtrl
+	GetCommentCtrl() *CommentCtrl
+	GetArticleLikeCtrl() *ArticleLikeCtrl
+	GetArticleRevisionCtrl() *ArticleRevisionCtrl
+	GetPublicCtrl() *PublicCtrl
+	GetArticleTaxonomyCtrl() *ArticleTaxonomyCtrl
+	GetEsOutboxCtrl() *EsOutboxCtrl
+	GetFeedCtrl() *FeedCtrl
+	GetSeoCtrl() *SeoCtrl
+	GetArticleExportCtrl() *ArticleExportCtrl
+	GetArticleSeriesCtrl() *ArticleSeriesCtrl
 }
 
 // SetUp 工厂函数-单例
@@ -30,7 +40,40 @@ func SetUp(service *service.Group) Supplier {
 		jwtService:   service.SystemServiceSupplier.GetJWTSvc(),
 	}
 	cs.articleCtrl = &ArticleCtrl{
-		articleSvc: service.SystemServiceSupplier.GetArticleSvc(),
+		articleSvc:     service.SystemServiceSupplier.GetArticleSvc(),
+		articleViewSvc: service.SystemServiceSupplier.GetArticleViewSvc(),
+	}
+	cs.commentCtrl = &CommentCtrl{
+		commentSvc: service.SystemServiceSupplier.GetCommentSvc(),
+	}
+	cs.articleLikeCtrl = &ArticleLikeCtrl{
+		articleLikeSvc: service.SystemServiceSupplier.GetArticleLikeSvc(),
+	}
+	cs.articleRevisionCtrl = &ArticleRevisionCtrl{
+		articleRevisionSvc: service.SystemServiceSupplier.GetArticleRevisionSvc(),
+	}
+	cs.publicCtrl = &PublicCtrl{
+		articleSvc:       service.SystemServiceSupplier.GetArticleSvc(),
+		articleViewSvc:   service.SystemServiceSupplier.GetArticleViewSvc(),
+		articleSeriesSvc: service.SystemServiceSupplier.GetArticleSeriesSvc(),
+	}
+	cs.articleTaxonomyCtrl = &ArticleTaxonomyCtrl{
+		articleTaxonomySvc: service.SystemServiceSupplier.GetArticleTaxonomySvc(),
+	}
+	cs.esOutboxCtrl = &EsOutboxCtrl{
+		esOutboxSvc: service.SystemServiceSupplier.GetEsOutboxSvc(),
+	}
+	cs.feedCtrl = &FeedCtrl{
+		feedSvc: service.SystemServiceSupplier.GetFeedSvc(),
+	}
+	cs.seoCtrl = &SeoCtrl{
+		seoSvc: service.SystemServiceSupplier.GetSeoSvc(),
+	}
+	cs.articleExportCtrl = &ArticleExportCtrl{
+		articleExportSvc: service.SystemServiceSupplier.GetArticleExportSvc(),
+	}
+	cs.articleSeriesCtrl = &ArticleSeriesCtrl{
+		articleSeriesSvc: service.SystemServiceSupplier.GetArticleSeriesSvc(),
 	}
 	return cs
 }
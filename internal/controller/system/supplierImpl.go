@@ -1,11 +1,21 @@
 package system
 
 type controllerSupplier struct {
-	refreshTokenCtrl *RefreshTokenCtrl
-	baseCtrl         *BaseCtrl
-	userCtrl         *UserCtrl
-	imageCtrl        *ImageCtrl
-	articleCtrl      *ArticleCtrl
+	refreshTokenCtrl    *RefreshTokenCtrl
+	baseCtrl            *BaseCtrl
+	userCtrl            *UserCtrl
+	imageCtrl           *ImageCtrl
+	articleCtrl         *ArticleCtrl
+	commentCtrl         *CommentCtrl
+	articleLikeCtrl     *ArticleLikeCtrl
+	articleRevisionCtrl *ArticleRevisionCtrl
+	publicCtrl          *PublicCtrl
+	articleTaxonomyCtrl *ArticleTaxonomyCtrl
+	esOutboxCtrl        *EsOutboxCtrl
+	feedCtrl            *FeedCtrl
+	seoCtrl             *SeoCtrl
+	articleExportCtrl   *ArticleExportCtrl
+	articleSeriesCtrl   *ArticleSeriesCtrl
 }
 
 func (c *controllerSupplier) GetRefreshTokenCtrl() *RefreshTokenCtrl {
@@ -25,3 +35,43 @@ func (c *controllerSupplier) GetImageCtrl() *ImageCtrl {
 func (c *controllerSupplier) GetArticleCtrl() *ArticleCtrl {
 	return c.articleCtrl
 }
+
+func (c *controllerSupplier) GetCommentCtrl() *CommentCtrl {
+	return c.commentCtrl
+}
+
+func (c *controllerSupplier) GetArticleLikeCtrl() *ArticleLikeCtrl {
+	return c.articleLikeCtrl
+}
+
+func (c *controllerSupplier) GetArticleRevisionCtrl() *ArticleRevisionCtrl {
+	return c.articleRevisionCtrl
+}
+
+func (c *controllerSupplier) GetPublicCtrl() *PublicCtrl {
+	return c.publicCtrl
+}
+
+func (c *controllerSupplier) GetArticleTaxonomyCtrl() *ArticleTaxonomyCtrl {
+	return c.articleTaxonomyCtrl
+}
+
+func (c *controllerSupplier) GetEsOutboxCtrl() *EsOutboxCtrl {
+	return c.esOutboxCtrl
+}
+
+func (c *controllerSupplier) GetFeedCtrl() *FeedCtrl {
+	return c.feedCtrl
+}
+
+func (c *controllerSupplier) GetSeoCtrl() *SeoCtrl {
+	return c.seoCtrl
+}
+
+func (c *controllerSupplier) GetArticleExportCtrl() *ArticleExportCtrl {
+	return c.articleExportCtrl
+}
+
+func (c *controllerSupplier) GetArticleSeriesCtrl() *ArticleSeriesCtrl {
+	return c.articleSeriesCtrl
+}
@@ -60,6 +60,10 @@ func InitConfig(path string) {
 	_ = viper.BindEnv("es.username", "ES_USERNAME")
 	_ = viper.BindEnv("es.password", "ES_PASSWORD")
 	_ = viper.BindEnv("es.is_console_print", "ES_IS_CONSOLE_PRINT")
+	_ = viper.BindEnv("es.analyzer", "ES_ANALYZER")
+	_ = viper.BindEnv("es.search_analyzer", "ES_SEARCH_ANALYZER")
+	_ = viper.BindEnv("es.outbox_spec", "ES_OUTBOX_SPEC")
+	_ = viper.BindEnv("es.outbox_attempts", "ES_OUTBOX_ATTEMPTS")
 
 	// 绑定高德地图相关配置到环境变量
 	_ = viper.BindEnv("gaode.enable", "GAODE_ENABLE")
@@ -139,6 +143,20 @@ func InitConfig(path string) {
 	_ = viper.BindEnv("zap.max_age", "ZAP_MAX_AGE")
 	_ = viper.BindEnv("zap.is_console_print", "ZAP_IS_CONSOLE_PRINT")
 
+	// 绑定文章相关配置到环境变量
+	_ = viper.BindEnv("article.view_dedup_window", "ARTICLE_VIEW_DEDUP_WINDOW")
+	_ = viper.BindEnv("article.view_flush_spec", "ARTICLE_VIEW_FLUSH_SPEC")
+	_ = viper.BindEnv("article.publish_spec", "ARTICLE_PUBLISH_SPEC")
+	_ = viper.BindEnv("article.reconcile_spec", "ARTICLE_RECONCILE_SPEC")
+	_ = viper.BindEnv("article.reconcile_repair", "ARTICLE_RECONCILE_REPAIR")
+	_ = viper.BindEnv("article.feed_size", "ARTICLE_FEED_SIZE")
+	_ = viper.BindEnv("article.feed_cache_ttl", "ARTICLE_FEED_CACHE_TTL")
+
+	// 绑定搜索引擎优化相关配置到环境变量（列表用空格分隔）
+	_ = viper.BindEnv("seo.robots_allow", "SEO_ROBOTS_ALLOW")
+	_ = viper.BindEnv("seo.robots_disallow", "SEO_ROBOTS_DISALLOW")
+	_ = viper.BindEnv("seo.robots_extra", "SEO_ROBOTS_EXTRA")
+
 	global.Log.Info("--------- configs list--------\n")
 	for _, key := range viper.AllKeys() {
 		global.Log.Info("configs",
@@ -39,8 +39,6 @@ func Init() {
 	core.InitCasbin()
 	// 初始化flag
 	flag.InitFlag()
-	// 启动定时任务
-	core.InitCron()
 
 	// 初始化Repository层
 	mysqlAdapter := &adapter.MySQLAdapter{}
@@ -62,6 +60,9 @@ func Init() {
 	controller.ApiGroupApp = &controller.ApiGroup{
 		SystemApiGroup: apiSystem.SetUp(service.GroupApp),
 	}
+	// 启动定时任务（任务依赖业务服务，需在服务初始化之后启动）
+	core.InitCron()
+
 	// 同步权限数据
 	permissionService := service.GroupApp.SystemServiceSupplier.GetPermissionSvc()
 	if err := permissionService.SyncAllPermissionsToCasbin(ctx); err != nil {
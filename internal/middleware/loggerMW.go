@@ -61,6 +61,10 @@ func GinRecovery(stack bool) gin.HandlerFunc {
 		defer func() {
 			// 检查是否发生了 panic 错误
 			if err := recover(); err != nil {
+				// 处理函数主动中断连接（如流式下载中途失败），继续抛出交给 net/http 直接关闭连接
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
 				// 检查是否是连接被断开的问题（如 broken pipe），这些错误不需要记录堆栈信息
 				var brokenPipe bool
 				if ne, ok := err.(*net.OpError); ok {
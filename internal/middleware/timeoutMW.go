@@ -13,12 +13,22 @@ import (
 
 // TimeoutMiddleware 请求超时中间件
 // timeout: 超时时间，如果为0则使用默认的30秒
-func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+// skipPaths: 不受超时限制的路由（如流式下载），由处理函数自行控制超时
+func TimeoutMiddleware(timeout time.Duration, skipPaths ...string) gin.HandlerFunc {
 	if timeout == 0 {
 		timeout = 30 * time.Second // 默认30秒超时
 	}
+	skips := make(map[string]struct{}, len(skipPaths))
+	for _, path := range skipPaths {
+		skips[path] = struct{}{}
+	}
 
 	return func(c *gin.Context) {
+		if _, ok := skips[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+
 		// 创建带超时的context
 		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
 		defer cancel()
@@ -0,0 +1,12 @@
+package config
+
+// Article 文章相关配置
+type Article struct {
+	ViewDedupWindow string `json:"view_dedup_window" yaml:"view_dedup_window"` // 同一访客重复浏览的去重窗口，如 30m，窗口内多次浏览只计一次
+	ViewFlushSpec   string `json:"view_flush_spec" yaml:"view_flush_spec"`     // 浏览量增量刷入 ES 的 cron 表达式，如 @every 1m
+	PublishSpec     string `json:"publish_spec" yaml:"publish_spec"`           // 定时发布文章的扫描周期（cron 表达式），如 @every 1m
+	ReconcileSpec   string `json:"reconcile_spec" yaml:"reconcile_spec"`       // 分类/标签计数对账周期（cron 表达式），如 @daily
+	ReconcileRepair bool   `json:"reconcile_repair" yaml:"reconcile_repair"`   // 定时对账发现偏差时是否自动修复，false 仅记录日志
+	FeedSize        int    `json:"feed_size" yaml:"feed_size"`                 // RSS/Atom 订阅源包含的最新文章数
+	FeedCacheTTL    string `json:"feed_cache_ttl" yaml:"feed_cache_ttl"`       // 订阅源缓存时长，如 1h；文章写入时缓存会立即失效
+}
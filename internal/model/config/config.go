@@ -17,6 +17,8 @@ type Config struct {
     Website Website `json:"website" yaml:"website"` // 个人网站配置
     Storage Storage `json:"storage" yaml:"storage"` // 存储驱动配置
     Static  Static  `json:"static" yaml:"static"`   // 静态文件配置
+    Article Article `json:"article" yaml:"article"` // 文章相关配置
+    SEO     SEO     `json:"seo" yaml:"seo"`         // 搜索引擎优化配置
 }
 
 func NewConfig() *Config {
@@ -26,6 +28,10 @@ func NewConfig() *Config {
 		Username:       viper.GetString("es.username"),
 		Password:       viper.GetString("es.password"),
 		IsConsolePrint: viper.GetBool("es.is_console_print"),
+		Analyzer:       viper.GetString("es.analyzer"),
+		SearchAnalyzer: viper.GetString("es.search_analyzer"),
+		OutboxSpec:     viper.GetString("es.outbox_spec"),
+		OutboxAttempts: viper.GetInt("es.outbox_attempts"),
 	}
 	// Redis配置初始化
 	_redis := &Redis{
@@ -148,6 +154,22 @@ func NewConfig() *Config {
 		QqImage:              viper.GetString("website.qq_image"),
 		WechatImage:          viper.GetString("website.wechat_image"),
 	}
+	// 文章相关配置初始化
+	_article := &Article{
+		ViewDedupWindow: viper.GetString("article.view_dedup_window"),
+		ViewFlushSpec:   viper.GetString("article.view_flush_spec"),
+		PublishSpec:     viper.GetString("article.publish_spec"),
+		ReconcileSpec:   viper.GetString("article.reconcile_spec"),
+		ReconcileRepair: viper.GetBool("article.reconcile_repair"),
+		FeedSize:        viper.GetInt("article.feed_size"),
+		FeedCacheTTL:    viper.GetString("article.feed_cache_ttl"),
+	}
+	// 搜索引擎优化配置初始化
+	_seo := &SEO{
+		RobotsAllow:    viper.GetStringSlice("seo.robots_allow"),
+		RobotsDisallow: viper.GetStringSlice("seo.robots_disallow"),
+		RobotsExtra:    viper.GetString("seo.robots_extra"),
+	}
 
 	return &Config{
 		ES:      *_es,
@@ -163,5 +185,7 @@ func NewConfig() *Config {
 		Static:  *_static,
 		Gaode:   *_gaode,
 		Website: *_website,
+		Article: *_article,
+		SEO:     *_seo,
 	}
 }
@@ -6,4 +6,8 @@ type ES struct {
 	Username       string `json:"username" yaml:"username"`                 // 用于连接 Elasticsearch 的用户名
 	Password       string `json:"password" yaml:"password"`                 // 用于连接 Elasticsearch 的密码
 	IsConsolePrint bool   `json:"is_console_print" yaml:"is_console_print"` // 是否在控制台打印 Elasticsearch 语句，true 表示打印，false 表示不打印
+	Analyzer       string `json:"analyzer" yaml:"analyzer"`                 // 文章标题/摘要/正文的索引分词器，如 ik_max_word、smartcn，插件缺失时回退为 standard
+	SearchAnalyzer string `json:"search_analyzer" yaml:"search_analyzer"`   // 搜索时使用的分词器，如 ik_smart，为空时与 analyzer 一致
+	OutboxSpec     string `json:"outbox_spec" yaml:"outbox_spec"`           // 发件箱中待同步操作的重试周期（cron 表达式），如 @every 30s
+	OutboxAttempts int    `json:"outbox_attempts" yaml:"outbox_attempts"`   // 单条操作最多尝试次数，耗尽后进入死信
 }
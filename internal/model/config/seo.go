@@ -0,0 +1,8 @@
+package config
+
+// SEO 搜索引擎优化相关配置
+type SEO struct {
+	RobotsAllow    []string `json:"robots_allow" yaml:"robots_allow"`       // robots.txt 中允许抓取的路径
+	RobotsDisallow []string `json:"robots_disallow" yaml:"robots_disallow"` // robots.txt 中禁止抓取的路径
+	RobotsExtra    string   `json:"robots_extra" yaml:"robots_extra"`       // 原样追加到 robots.txt 末尾的内容（如针对特定爬虫的规则）
+}
@@ -0,0 +1,33 @@
+package consts
+
+// ArticleStatus 文章状态
+type ArticleStatus int
+
+const (
+	ArticleStatusNone ArticleStatus = iota // 未设置（历史文章，按已发布处理）
+	ArticleDraft                           // 草稿
+	ArticlePublished                       // 已发布
+	ArticleScheduled                       // 定时发布
+	ArticleArchived                        // 已归档
+)
+
+// String 方法返回 ArticleStatus 的字符串表示
+func (s ArticleStatus) String() string {
+	switch s {
+	case ArticleStatusNone, ArticlePublished:
+		return "已发布"
+	case ArticleDraft:
+		return "草稿"
+	case ArticleScheduled:
+		return "定时发布"
+	case ArticleArchived:
+		return "已归档"
+	default:
+		return "未知状态"
+	}
+}
+
+// IsPublished 是否为读者可见的已发布状态（未设置状态的历史文章视为已发布）
+func (s ArticleStatus) IsPublished() bool {
+	return s == ArticleStatusNone || s == ArticlePublished
+}
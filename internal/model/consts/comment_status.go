@@ -0,0 +1,24 @@
+package consts
+
+// CommentStatus 评论状态
+type CommentStatus int
+
+const (
+	CommentHidden  CommentStatus = iota // 隐藏
+	CommentNormal                       // 正常
+	CommentPending                      // 待审核
+)
+
+// String 方法返回 CommentStatus 的字符串表示
+func (s CommentStatus) String() string {
+	switch s {
+	case CommentHidden:
+		return "隐藏"
+	case CommentNormal:
+		return "正常"
+	case CommentPending:
+		return "待审核"
+	default:
+		return "未知状态"
+	}
+}
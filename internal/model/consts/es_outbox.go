@@ -0,0 +1,44 @@
+package consts
+
+// EsOutboxOp 待同步到 ES 的操作类型
+type EsOutboxOp int
+
+const (
+	EsOutboxIndex  EsOutboxOp = iota + 1 // 写入（创建或整体覆盖）文档
+	EsOutboxUpdate                       // 局部更新文档
+	EsOutboxDelete                       // 删除文档
+)
+
+// String 方法返回 EsOutboxOp 的字符串表示
+func (o EsOutboxOp) String() string {
+	switch o {
+	case EsOutboxIndex:
+		return "写入"
+	case EsOutboxUpdate:
+		return "更新"
+	case EsOutboxDelete:
+		return "删除"
+	default:
+		return "未知操作"
+	}
+}
+
+// EsOutboxStatus 待同步操作的状态（同步成功的记录直接删除）
+type EsOutboxStatus int
+
+const (
+	EsOutboxPending EsOutboxStatus = iota + 1 // 待同步（含等待重试）
+	EsOutboxDead                              // 重试耗尽，进入死信，需人工处理
+)
+
+// String 方法返回 EsOutboxStatus 的字符串表示
+func (s EsOutboxStatus) String() string {
+	switch s {
+	case EsOutboxPending:
+		return "待同步"
+	case EsOutboxDead:
+		return "死信"
+	default:
+		return "未知状态"
+	}
+}
@@ -0,0 +1,31 @@
+package consts
+
+// FeedFormat 订阅源格式
+type FeedFormat int
+
+const (
+	FeedRSS  FeedFormat = iota + 1 // RSS 2.0
+	FeedAtom                       // Atom 1.0
+)
+
+// String 方法返回 FeedFormat 的字符串表示
+func (f FeedFormat) String() string {
+	switch f {
+	case FeedRSS:
+		return "rss"
+	case FeedAtom:
+		return "atom"
+	default:
+		return "unknown"
+	}
+}
+
+// ContentType 订阅源响应的 Content-Type
+func (f FeedFormat) ContentType() string {
+	switch f {
+	case FeedAtom:
+		return "application/atom+xml; charset=utf-8"
+	default:
+		return "application/rss+xml; charset=utf-8"
+	}
+}
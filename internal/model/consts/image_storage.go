@@ -9,3 +9,13 @@ const (
 	Local Storage = iota // 本地
 	Qiniu                // 七牛云
 )
+
+// String 存储类型对应的驱动名，与 storage 包中注册的驱动名一致
+func (s Storage) String() string {
+	switch s {
+	case Qiniu:
+		return "qiniu"
+	default:
+		return "local"
+	}
+}
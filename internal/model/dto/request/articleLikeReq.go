@@ -0,0 +1,11 @@
+package request
+
+// ArticleLikeToggleReq 收藏/取消收藏请求体
+type ArticleLikeToggleReq struct {
+	ArticleID string `json:"article_id" binding:"required"` // elasticsearch中文章对应的ID
+}
+
+// ArticleLikeStatusReq 查询是否已收藏请求体
+type ArticleLikeStatusReq struct {
+	ArticleID string `json:"article_id" form:"article_id" binding:"required"` // elasticsearch中文章对应的ID
+}
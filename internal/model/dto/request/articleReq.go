@@ -1,5 +1,7 @@
 package request
 
+import "personal_blog/internal/model/consts"
+
 // ArticleCreateReq 创建文章请求体
 type ArticleCreateReq struct {
 	Cover        string   `json:"cover" binding:"required"`      // 封面url
@@ -9,6 +11,9 @@ type ArticleCreateReq struct {
 	Abstract     string   `json:"abstract" binding:"required"`   // 摘要
 	Content      string   `json:"content" binding:"required"`    // 文章内容
 	VisibleRange uint     `json:"visible_range" bind:"required"` // 1-"全部可见"/2-"仅我可见"
+
+	Status    consts.ArticleStatus `json:"status"`     // 文章状态 1-草稿/2-已发布/3-定时发布/4-已归档，不传时默认发布
+	PublishAt string               `json:"publish_at"` // 定时发布时间，格式 2006-01-02 15:04:05，定时发布时必填
 }
 
 // ArticleDeleteReq 删除文章请求体
@@ -26,15 +31,85 @@ type ArticleUpdateReq struct {
 	Abstract     string   `json:"abstract" binding:"required"`   // 摘要
 	Content      string   `json:"content" binding:"required"`    // 文章内容
 	VisibleRange uint     `json:"visible_range" bind:"required"` // 1-"全部可见"/2-"仅我可见"
+
+	Status    consts.ArticleStatus `json:"status"`     // 文章状态 1-草稿/2-已发布/3-定时发布/4-已归档，不传时保持原状态
+	PublishAt string               `json:"publish_at"` // 定时发布时间，格式 2006-01-02 15:04:05，定时发布时必填
 }
 
 // ArticleListReq 查询文章请求体
 type ArticleListReq struct {
-	ID           *string `json:"id" form:"id"`                  // ID
-	Title        *string `json:"title" form:"title"`            // 标题
-	Category     *string `json:"category" form:"category"`      // 专栏
-	Tag          *string `json:"tag" form:"tag"`                // 标签
-	Abstract     *string `json:"abstract" form:"abstract"`      // 摘要
-	VisibleRange uint    `json:"visible_range" bind:"required"` // 1-"全部可见"/2-"仅我可见"
-	PageInfo             // 分页信息
+	ID           *string               `json:"id" form:"id"`                  // ID
+	Title        *string               `json:"title" form:"title"`            // 标题
+	Category     *string               `json:"category" form:"category"`      // 专栏
+	Tag          *string               `json:"tag" form:"tag"`                // 标签
+	Abstract     *string               `json:"abstract" form:"abstract"`      // 摘要
+	VisibleRange uint                  `json:"visible_range" bind:"required"` // 1-"全部可见"/2-"仅我可见"
+	Status       *consts.ArticleStatus `json:"status" form:"status"`          // 文章状态（仅作者视角生效，读者只能看到已发布文章）
+	PageInfo                           // 分页信息
+	CursorInfo                         // 游标分页信息
+}
+
+// ArticlePublicListReq 公开文章列表请求体（匿名访问）
+type ArticlePublicListReq struct {
+	Title      *string `json:"title" form:"title"`       // 标题
+	Category   *string `json:"category" form:"category"` // 专栏
+	Tag        *string `json:"tag" form:"tag"`           // 标签
+	Abstract   *string `json:"abstract" form:"abstract"` // 摘要
+	PageInfo           // 分页信息
+	CursorInfo         // 游标分页信息
+}
+
+// ArticleDetailReq 文章详情请求体
+type ArticleDetailReq struct {
+	ID string `json:"id" form:"id" binding:"required"` // elasticsearch中每篇文章对应的ID
+}
+
+// ArticleSearchReq 关键字全文搜索请求体
+type ArticleSearchReq struct {
+	Keyword    string  `json:"keyword" form:"keyword" binding:"required"` // 关键字，在标题、摘要、正文中检索
+	Category   *string `json:"category" form:"category"`                  // 专栏
+	Tag        *string `json:"tag" form:"tag"`                            // 标签
+	PageInfo           // 分页信息
+	CursorInfo         // 游标分页信息
+}
+
+// ArticleSuggestReq 标题联想请求体
+type ArticleSuggestReq struct {
+	Keyword string `json:"keyword" form:"keyword" binding:"required"` // 已输入的内容（按前缀匹配标题）
+	Size    int    `json:"size" form:"size"`                          // 返回条数，默认 5，最多 20
+}
+
+// ArticleRelatedReq 相关文章推荐请求体
+type ArticleRelatedReq struct {
+	ID   string `json:"id" form:"id" binding:"required"` // 当前文章ID
+	Size int    `json:"size" form:"size"`                // 返回条数，默认 5，最多 20
+}
+
+// ArticleArchiveMonthReq 按月查看归档请求体
+type ArticleArchiveMonthReq struct {
+	Year     int `json:"year" form:"year" binding:"required,min=1970"`       // 年份
+	Month    int `json:"month" form:"month" binding:"required,min=1,max=12"` // 月份 1-12
+	PageInfo     // 分页信息
+}
+
+// ArticleExportReq 导出文章请求体
+type ArticleExportReq struct {
+	ID string `json:"id" form:"id"` // 文章ID，为空时导出全部（管理员为全部文章，其他用户为自己的文章）
+}
+
+// ArticlePromoteReq 设置文章置顶/精选请求体
+// - 截止时间格式为 "2006-01-02 15:04:05"，为空表示长期有效；取消置顶/精选时忽略权重与截止时间
+type ArticlePromoteReq struct {
+	ID            string `json:"id" binding:"required"` // 文章ID
+	Pinned        bool   `json:"pinned"`                // 是否置顶
+	PinWeight     int    `json:"pin_weight"`            // 置顶权重，越大越靠前
+	PinUntil      string `json:"pin_until"`             // 置顶截止时间
+	Featured      bool   `json:"featured"`              // 是否精选（出现在首页轮播中）
+	FeatureWeight int    `json:"feature_weight"`        // 精选权重，越大越靠前
+	FeatureUntil  string `json:"feature_until"`         // 精选截止时间
+}
+
+// ArticleCarouselReq 首页轮播请求体
+type ArticleCarouselReq struct {
+	Size int `json:"size" form:"size"` // 返回条数，默认 5，最多 20
 }
@@ -0,0 +1,18 @@
+package request
+
+// ArticleRevisionListReq 查询文章历史版本请求体
+type ArticleRevisionListReq struct {
+	ArticleID string `json:"article_id" form:"article_id" binding:"required"` // elasticsearch中文章对应的ID
+	PageInfo         // 分页信息
+}
+
+// ArticleRevisionDiffReq 对比两个历史版本请求体
+type ArticleRevisionDiffReq struct {
+	From uint `json:"from" form:"from" binding:"required"` // 旧版本ID
+	To   uint `json:"to" form:"to" binding:"required"`     // 新版本ID
+}
+
+// ArticleRevisionRestoreReq 恢复历史版本请求体
+type ArticleRevisionRestoreReq struct {
+	RevisionID uint `json:"revision_id" binding:"required"` // 要恢复的版本ID
+}
@@ -0,0 +1,38 @@
+package request
+
+// ArticleSeriesCreateReq 创建专栏请求体
+type ArticleSeriesCreateReq struct {
+	Title       string `json:"title" binding:"required,max=255"` // 专栏标题
+	Description string `json:"description"`                      // 专栏简介
+	Cover       string `json:"cover"`                            // 封面url
+}
+
+// ArticleSeriesUpdateReq 更新专栏请求体
+type ArticleSeriesUpdateReq struct {
+	ID          uint   `json:"id" binding:"required"`            // 专栏ID
+	Title       string `json:"title" binding:"required,max=255"` // 专栏标题
+	Description string `json:"description"`                      // 专栏简介
+	Cover       string `json:"cover"`                            // 封面url
+}
+
+// ArticleSeriesDeleteReq 删除专栏请求体
+type ArticleSeriesDeleteReq struct {
+	ID uint `json:"id" binding:"required"` // 专栏ID
+}
+
+// ArticleSeriesArticlesReq 设置专栏文章及顺序请求体
+// - 按数组顺序排列，未出现在数组中的原有文章会被移出专栏，传空数组清空专栏
+type ArticleSeriesArticlesReq struct {
+	ID         uint     `json:"id" binding:"required"`               // 专栏ID
+	ArticleIDs []string `json:"article_ids" binding:"dive,required"` // 按顺序排列的文章ID
+}
+
+// ArticleSeriesDetailReq 专栏详情请求体
+type ArticleSeriesDetailReq struct {
+	ID uint `json:"id" form:"id" binding:"required"` // 专栏ID
+}
+
+// ArticleSeriesListReq 专栏列表请求体
+type ArticleSeriesListReq struct {
+	PageInfo // 分页信息
+}
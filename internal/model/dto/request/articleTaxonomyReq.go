@@ -0,0 +1,19 @@
+package request
+
+// ArticleTaxonomyListReq 分类/标签列表请求体（用于标签云等场景）
+type ArticleTaxonomyListReq struct {
+	Sort  string `json:"sort" form:"sort"`   // 排序方式：count-按文章数倒序（默认）/name-按名称升序
+	Limit int    `json:"limit" form:"limit"` // 返回数量，不传或<=0时返回全部
+}
+
+// CategoryRenameReq 重命名分类请求体（目标分类已存在时合并到目标分类）
+type CategoryRenameReq struct {
+	From string `json:"from" binding:"required"` // 原分类
+	To   string `json:"to" binding:"required"`   // 新分类
+}
+
+// TagMergeReq 合并标签请求体（也可用于重命名单个标签）
+type TagMergeReq struct {
+	Sources []string `json:"sources" binding:"required,min=1"` // 被合并的标签
+	Target  string   `json:"target" binding:"required"`        // 合并后的标签
+}
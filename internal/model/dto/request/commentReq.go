@@ -0,0 +1,19 @@
+package request
+
+// CommentCreateReq 发表评论请求体
+type CommentCreateReq struct {
+	ArticleID string `json:"article_id" binding:"required"`       // elasticsearch中文章对应的ID
+	ParentID  uint   `json:"parent_id"`                           // 回复的评论ID，0表示直接评论文章
+	Content   string `json:"content" binding:"required,max=1000"` // 评论内容
+}
+
+// CommentListReq 查询评论请求体
+type CommentListReq struct {
+	ArticleID string `json:"article_id" form:"article_id" binding:"required"` // elasticsearch中文章对应的ID
+	PageInfo         // 分页信息（按顶级评论分页）
+}
+
+// CommentDeleteReq 删除评论请求体
+type CommentDeleteReq struct {
+	ID uint `json:"id" binding:"required"` // 评论ID，其下所有回复会一并删除
+}
@@ -4,3 +4,9 @@ type PageInfo struct {
 	Page     int `json:"page" form:"page"`
 	PageSize int `json:"page_size" form:"page_size"`
 }
+
+// CursorInfo 游标分页信息，传入 cursor 时按游标翻页并忽略页码，可翻过 ES 的 max_result_window 限制
+// - 首次请求传空值（?cursor=），之后传上一次响应中的 next_cursor，next_cursor 为空表示没有更多数据
+type CursorInfo struct {
+	Cursor *string `json:"cursor" form:"cursor"`
+}
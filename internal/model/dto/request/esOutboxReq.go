@@ -0,0 +1,11 @@
+package request
+
+// EsOutboxDeadListReq 查询 ES 同步死信请求体
+type EsOutboxDeadListReq struct {
+	PageInfo // 分页信息
+}
+
+// EsOutboxRequeueReq 死信重新入队请求体
+type EsOutboxRequeueReq struct {
+	IDs []uint `json:"ids" binding:"required,min=1"` // 死信ID
+}
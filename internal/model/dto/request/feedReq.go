@@ -0,0 +1,7 @@
+package request
+
+// FeedReq 订阅源请求：分类与标签都为空时为全站订阅源
+type FeedReq struct {
+	Category string `uri:"category"` // 分类订阅源
+	Tag      string `uri:"tag"`      // 标签订阅源
+}
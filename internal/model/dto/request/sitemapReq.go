@@ -0,0 +1,6 @@
+package request
+
+// SitemapPageReq 分页站点地图请求：page 形如 1.xml
+type SitemapPageReq struct {
+	Page string `uri:"page" binding:"required"` // 页码（从 1 开始）+ .xml
+}
@@ -0,0 +1,22 @@
+package response
+
+// ArchiveArticleResp 归档中的单篇文章
+type ArchiveArticleResp struct {
+	ID        string `json:"id"`         // elasticsearch中每篇文章对应的ID
+	Title     string `json:"title"`      // 标题
+	CreatedAt string `json:"created_at"` // 创建时间
+}
+
+// ArchiveMonthResp 归档中的一个月
+type ArchiveMonthResp struct {
+	Month    int                  `json:"month"`    // 月份 1-12
+	Count    int64                `json:"count"`    // 当月文章数
+	Articles []ArchiveArticleResp `json:"articles"` // 当月最新的文章（数量较多时通过按月查询分页获取其余文章）
+}
+
+// ArchiveYearResp 归档中的一年
+type ArchiveYearResp struct {
+	Year   int                `json:"year"`   // 年份
+	Count  int64              `json:"count"`  // 当年文章数
+	Months []ArchiveMonthResp `json:"months"` // 各月归档（按月份倒序）
+}
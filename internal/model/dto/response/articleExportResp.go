@@ -0,0 +1,8 @@
+package response
+
+// ArticleExportResp 文章导出结果
+type ArticleExportResp struct {
+	Articles int      `json:"articles"` // 导出的文章数
+	Images   int      `json:"images"`   // 打包的图片数
+	Missing  []string `json:"missing"`  // 未能打包、保留原地址的图片（外部图片或下载失败）
+}
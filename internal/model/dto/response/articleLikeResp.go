@@ -0,0 +1,8 @@
+package response
+
+// ArticleLikeResp 收藏状态响应
+type ArticleLikeResp struct {
+	ArticleID string `json:"article_id"` // 文章ID
+	Liked     bool   `json:"liked"`      // 当前用户是否已收藏
+	Likes     int    `json:"likes"`      // 文章收藏总数
+}
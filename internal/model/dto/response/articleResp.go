@@ -3,7 +3,9 @@ package response
 import (
 	"encoding/json"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"personal_blog/internal/model/consts"
 	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/pkg/markdown"
 )
 
 // ArticleItemResp 文章响应结构体（用于API返回）
@@ -24,6 +26,20 @@ type ArticleItemResp struct {
 	Views        int      `json:"views"`             // 浏览次数
 	Comments     int      `json:"comments"`          // 评论数量
 	Likes        int      `json:"likes"`             // 点赞数量
+
+	ContentHTML string             `json:"content_html,omitempty"` // 正文渲染后的 HTML（随正文返回）
+	TOC         []markdown.Heading `json:"toc,omitempty"`          // 正文目录（随正文返回）
+
+	AuthorID  uint                 `json:"author_id"`            // 作者用户ID
+	Status    consts.ArticleStatus `json:"status"`               // 文章状态
+	PublishAt string               `json:"publish_at,omitempty"` // 发布时间
+
+	Pinned   bool `json:"pinned"`   // 是否置顶
+	Featured bool `json:"featured"` // 是否精选
+
+	Highlight map[string][]string `json:"highlight,omitempty"` // 搜索高亮片段（字段名 -> 片段列表），仅搜索结果返回
+
+	Series *ArticleSeriesNavResp `json:"series,omitempty"` // 所属专栏及前后篇导航，仅文章详情返回
 }
 
 // ArticleListResp 文章列表响应
@@ -33,10 +49,13 @@ type ArticleListResp struct {
     Page  int               `json:"page"`
     PageSize int            `json:"page_size"`
     TotalPages int          `json:"total_pages"`
+
+    // 游标分页时返回下一页的游标，为空表示没有更多数据；游标分页不返回页码与总页数
+    NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // FromArticle 将 ES 文档与 `_id` 映射为响应结构
-// - includeContent=false 时不填充 Content 字段
+// - includeContent=false 时不填充 Content、ContentHTML、TOC 字段
 func FromArticle(id string, a esModel.Article, includeContent bool) ArticleItemResp {
 	item := ArticleItemResp{
 		ID:           id,
@@ -48,13 +67,20 @@ func FromArticle(id string, a esModel.Article, includeContent bool) ArticleItemR
 		Category:     a.Category,
 		Tags:         a.Tags,
 		Abstract:     a.Abstract,
-		VisibleRange: a.VisibleRange,
+		VisibleRange: a.Visibility(),
 		Views:        a.Views,
 		Comments:     a.Comments,
 		Likes:        a.Likes,
+		AuthorID:     a.AuthorID,
+		Status:       a.Status,
+		PublishAt:    a.PublishAt,
+		Pinned:       a.Pinned,
+		Featured:     a.Featured,
 	}
 	if includeContent {
 		item.Content = a.Content
+		item.ContentHTML = a.ContentHTML
+		item.TOC = a.TOC
 	}
 	return item
 }
@@ -72,8 +98,12 @@ func FromHit(hit types.Hit, includeContent bool) (ArticleItemResp, error) {
 	if hit.Id_ != nil {
 		id = *hit.Id_
 	}
-	// 3、返回响应结构
-	return FromArticle(id, src, includeContent), nil
+	// 3、返回响应结构（附带搜索高亮片段）
+	item := FromArticle(id, src, includeContent)
+	if len(hit.Highlight) > 0 {
+		item.Highlight = hit.Highlight
+	}
+	return item, nil
 }
 
 // FromHits 将多个 ES Hits 映射为响应结构切片
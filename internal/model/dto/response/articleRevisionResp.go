@@ -0,0 +1,64 @@
+package response
+
+import (
+	"personal_blog/internal/model/entity"
+	"personal_blog/pkg/util"
+)
+
+// ArticleRevisionItemResp 文章历史版本响应结构体
+type ArticleRevisionItemResp struct {
+	ID        uint     `json:"id"`                // 版本ID
+	ArticleID string   `json:"article_id"`        // 文章ID
+	Version   uint     `json:"version"`           // 版本号
+	Cover     string   `json:"cover"`             // 封面url
+	Title     string   `json:"title"`             // 标题
+	Category  string   `json:"category"`          // 分类
+	Tags      []string `json:"tags"`              // 标签
+	Abstract  string   `json:"abstract"`          // 摘要
+	Content   string   `json:"content,omitempty"` // 文章内容（列表中不返回）
+	EditorID  uint     `json:"editor_id"`         // 编辑者ID（0表示未知）
+	CreatedAt string   `json:"created_at"`        // 保存时间
+}
+
+// ArticleRevisionListResp 文章历史版本列表响应
+type ArticleRevisionListResp struct {
+	List       []ArticleRevisionItemResp `json:"list"`
+	Total      int64                     `json:"total"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+// ArticleRevisionDiffResp 两个历史版本的差异
+type ArticleRevisionDiffResp struct {
+	From         ArticleRevisionItemResp `json:"from"`          // 旧版本（不含内容）
+	To           ArticleRevisionItemResp `json:"to"`            // 新版本（不含内容）
+	Title        []util.DiffLine         `json:"title"`         // 标题差异
+	Abstract     []util.DiffLine         `json:"abstract"`      // 摘要差异
+	Content      []util.DiffLine         `json:"content"`       // 内容逐行差异
+	AddedTags    []string                `json:"added_tags"`    // 新增的标签
+	RemovedTags  []string                `json:"removed_tags"`  // 删除的标签
+	CategoryFrom string                  `json:"category_from"` // 旧分类
+	CategoryTo   string                  `json:"category_to"`   // 新分类
+}
+
+// FromArticleRevision 将历史版本实体映射为响应结构
+// - includeContent=false 时不填充 Content 字段
+func FromArticleRevision(r *entity.ArticleRevision, includeContent bool) ArticleRevisionItemResp {
+	item := ArticleRevisionItemResp{
+		ID:        r.ID,
+		ArticleID: r.ArticleID,
+		Version:   r.Version,
+		Cover:     r.Cover,
+		Title:     r.Title,
+		Category:  r.Category,
+		Tags:      r.Tags,
+		Abstract:  r.Abstract,
+		EditorID:  r.EditorID,
+		CreatedAt: r.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if includeContent {
+		item.Content = r.Content
+	}
+	return item
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"personal_blog/internal/model/entity"
+)
+
+// ArticleSeriesItemResp 专栏响应结构体
+type ArticleSeriesItemResp struct {
+	ID          uint   `json:"id"`          // 专栏ID
+	Title       string `json:"title"`       // 专栏标题
+	Description string `json:"description"` // 专栏简介
+	Cover       string `json:"cover"`       // 封面url
+	AuthorID    uint   `json:"author_id"`   // 创建者ID
+	CreatedAt   string `json:"created_at"`  // 创建时间
+	UpdatedAt   string `json:"updated_at"`  // 最后更新时间
+}
+
+// ArticleSeriesListResp 专栏列表响应
+type ArticleSeriesListResp struct {
+	List       []ArticleSeriesItemResp `json:"list"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+}
+
+// ArticleSeriesDetailResp 专栏详情：专栏信息与按顺序排列的文章（只含当前用户可见的文章，不含正文）
+type ArticleSeriesDetailResp struct {
+	ArticleSeriesItemResp
+	Articles []ArticleItemResp `json:"articles"` // 专栏中的文章
+}
+
+// ArticleSeriesChapterResp 专栏中的一篇文章（用于前后篇导航）
+type ArticleSeriesChapterResp struct {
+	ID    string `json:"id"`    // 文章ID
+	Title string `json:"title"` // 文章标题
+}
+
+// ArticleSeriesNavResp 文章所属专栏及专栏内的前后篇导航
+// - 序号与总数只计当前用户可见的文章
+type ArticleSeriesNavResp struct {
+	ID    uint                      `json:"id"`    // 专栏ID
+	Title string                    `json:"title"` // 专栏标题
+	Index int                       `json:"index"` // 当前文章在专栏中的序号（从1开始）
+	Total int                       `json:"total"` // 专栏中的文章数
+	Prev  *ArticleSeriesChapterResp `json:"prev"`  // 上一篇，当前为第一篇时为 null
+	Next  *ArticleSeriesChapterResp `json:"next"`  // 下一篇，当前为最后一篇时为 null
+}
+
+// FromArticleSeries 将专栏实体映射为响应结构
+func FromArticleSeries(s *entity.ArticleSeries) ArticleSeriesItemResp {
+	return ArticleSeriesItemResp{
+		ID:          s.ID,
+		Title:       s.Title,
+		Description: s.Description,
+		Cover:       s.Cover,
+		AuthorID:    s.AuthorID,
+		CreatedAt:   s.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   s.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
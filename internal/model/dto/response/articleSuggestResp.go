@@ -0,0 +1,7 @@
+package response
+
+// ArticleSuggestResp 标题联想结果
+type ArticleSuggestResp struct {
+	ID    string `json:"id"`    // elasticsearch中每篇文章对应的ID
+	Title string `json:"title"` // 标题
+}
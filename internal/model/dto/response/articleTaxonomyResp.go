@@ -0,0 +1,32 @@
+package response
+
+// CategoryCountResp 分类及其文章数
+type CategoryCountResp struct {
+	Category string `json:"category"` // 分类
+	Number   int64  `json:"number"`   // 文章数
+}
+
+// TagCountResp 标签及其文章数
+type TagCountResp struct {
+	Tag    string `json:"tag"`    // 标签
+	Number int64  `json:"number"` // 文章数
+}
+
+// TaxonomyUpdateResp 分类重命名/标签合并结果
+type TaxonomyUpdateResp struct {
+	Updated int64 `json:"updated"` // 被改写的文章数
+}
+
+// TaxonomyDriftResp 单个分类/标签的计数偏差
+type TaxonomyDriftResp struct {
+	Name     string `json:"name"`     // 分类名或标签名
+	Expected int64  `json:"expected"` // ES 中实际的文章数
+	Actual   int64  `json:"actual"`   // MySQL 中记录的计数
+}
+
+// ReconcileResp 分类/标签计数对账结果
+type ReconcileResp struct {
+	Categories []TaxonomyDriftResp `json:"categories"` // 存在偏差的分类
+	Tags       []TaxonomyDriftResp `json:"tags"`       // 存在偏差的标签
+	Repaired   bool                `json:"repaired"`   // 是否已修复
+}
@@ -0,0 +1,79 @@
+package response
+
+import (
+	"personal_blog/internal/model/entity"
+)
+
+// CommentItemResp 评论响应结构体（树形）
+type CommentItemResp struct {
+	ID        uint              `json:"id"`         // 评论ID
+	ArticleID string            `json:"article_id"` // 文章ID
+	UserID    uint              `json:"user_id"`    // 评论用户ID
+	Username  string            `json:"username"`   // 评论用户名
+	Avatar    string            `json:"avatar"`     // 评论用户头像
+	ParentID  uint              `json:"parent_id"`  // 父评论ID
+	RootID    uint              `json:"root_id"`    // 根评论ID
+	Content   string            `json:"content"`    // 评论内容
+	CreatedAt string            `json:"created_at"` // 创建时间
+	Children  []CommentItemResp `json:"children"`   // 子回复
+}
+
+// CommentListResp 评论列表响应
+type CommentListResp struct {
+	List       []CommentItemResp `json:"list"`
+	Total      int64             `json:"total"` // 顶级评论总数
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// FromComment 将评论实体映射为响应结构（不含子回复）
+func FromComment(c *entity.Comment) CommentItemResp {
+	return CommentItemResp{
+		ID:        c.ID,
+		ArticleID: c.ArticleID,
+		UserID:    c.UserID,
+		Username:  c.User.Username,
+		Avatar:    c.User.Avatar,
+		ParentID:  c.ParentID,
+		RootID:    c.RootID,
+		Content:   c.Content,
+		CreatedAt: c.CreatedAt.Format("2006-01-02 15:04:05"),
+		Children:  []CommentItemResp{},
+	}
+}
+
+// BuildCommentTree 将顶级评论与其下所有回复组装为评论树
+// - roots 为当前页的顶级评论
+// - replies 为这些顶级评论下的全部回复（按时间正序）
+func BuildCommentTree(roots, replies []*entity.Comment) []CommentItemResp {
+	// 1、先为每条评论建立节点
+	nodes := make(map[uint]*CommentItemResp, len(roots)+len(replies))
+	for _, c := range roots {
+		item := FromComment(c)
+		nodes[c.ID] = &item
+	}
+	for _, c := range replies {
+		item := FromComment(c)
+		nodes[c.ID] = &item
+	}
+	// 2、自底向上挂载子回复（replies 为时间正序，倒序挂载保证父节点拿到完整子树）
+	for i := len(replies) - 1; i >= 0; i-- {
+		c := replies[i]
+		parent, ok := nodes[c.ParentID]
+		if !ok {
+			// 父评论已被删除或隐藏，挂到根评论下
+			parent, ok = nodes[c.RootID]
+			if !ok {
+				continue
+			}
+		}
+		parent.Children = append([]CommentItemResp{*nodes[c.ID]}, parent.Children...)
+	}
+	// 3、按顶级评论顺序输出
+	out := make([]CommentItemResp, 0, len(roots))
+	for _, c := range roots {
+		out = append(out, *nodes[c.ID])
+	}
+	return out
+}
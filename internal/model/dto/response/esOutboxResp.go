@@ -0,0 +1,38 @@
+package response
+
+import "personal_blog/internal/model/entity"
+
+// EsOutboxItemResp ES 同步操作响应结构体
+type EsOutboxItemResp struct {
+	ID          uint   `json:"id"`            // 操作ID
+	ArticleID   string `json:"article_id"`    // 文章ID
+	Op          string `json:"op"`            // 操作类型
+	Status      string `json:"status"`        // 状态
+	Attempts    int    `json:"attempts"`      // 已尝试次数
+	LastError   string `json:"last_error"`    // 最近一次失败原因
+	CreatedAt   string `json:"created_at"`    // 登记时间
+	NextRetryAt string `json:"next_retry_at"` // 下次可执行时间
+}
+
+// EsOutboxListResp ES 同步操作列表响应
+type EsOutboxListResp struct {
+	List       []EsOutboxItemResp `json:"list"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// FromEsOutbox 将 ES 同步操作实体映射为响应结构
+func FromEsOutbox(o *entity.EsOutbox) EsOutboxItemResp {
+	return EsOutboxItemResp{
+		ID:          o.ID,
+		ArticleID:   o.ArticleID,
+		Op:          o.Op.String(),
+		Status:      o.Status.String(),
+		Attempts:    o.Attempts,
+		LastError:   o.LastError,
+		CreatedAt:   o.CreatedAt.Format("2006-01-02 15:04:05"),
+		NextRetryAt: o.NextRetryAt.Format("2006-01-02 15:04:05"),
+	}
+}
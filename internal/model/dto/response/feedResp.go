@@ -0,0 +1,81 @@
+package response
+
+import "encoding/xml"
+
+// RSS RSS 2.0 订阅源
+type RSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+// RSSChannel RSS 频道（站点信息 + 文章列表）
+type RSSChannel struct {
+	Title          string    `xml:"title"`
+	Link           string    `xml:"link"`
+	Description    string    `xml:"description"`
+	Language       string    `xml:"language,omitempty"`
+	ManagingEditor string    `xml:"managingEditor,omitempty"`
+	LastBuildDate  string    `xml:"lastBuildDate,omitempty"`
+	AtomLink       AtomLink  `xml:"atom:link"`
+	Items          []RSSItem `xml:"item"`
+}
+
+// RSSItem RSS 中的单篇文章
+type RSSItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        RSSGUID  `xml:"guid"`
+	Description string   `xml:"description,omitempty"`
+	Author      string   `xml:"author,omitempty"`
+	Categories  []string `xml:"category,omitempty"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+}
+
+// RSSGUID 文章唯一标识，使用文章地址
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// AtomFeed Atom 1.0 订阅源
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []AtomLink  `xml:"link"`
+	Author   *AtomAuthor `xml:"author,omitempty"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+// AtomLink Atom 链接（RSS 中用于声明自身地址）
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// AtomAuthor Atom 作者
+type AtomAuthor struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+}
+
+// AtomEntry Atom 中的单篇文章
+type AtomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       AtomLink       `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []AtomCategory `xml:"category,omitempty"`
+}
+
+// AtomCategory Atom 分类/标签
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import "encoding/xml"
+
+// SitemapURLSet 站点地图（单个文件最多 50000 条地址）
+type SitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+// SitemapURL 站点地图中的单条地址
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SitemapIndex 站点地图索引（地址数超过单个文件上限时分页）
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []SitemapURL `xml:"sitemap"`
+}
@@ -1,9 +1,19 @@
 package esMODEL
 
 import (
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"personal_blog/internal/model/consts"
 	"personal_blog/internal/model/dto/request"
+	"personal_blog/pkg/markdown"
+	"strconv"
+	"strings"
+)
+
+const (
+	VisiblePublic  uint = 1 // 全部可见
+	VisiblePrivate uint = 2 // 仅我可见
 )
 
 // Article 文章表
@@ -11,18 +21,53 @@ type Article struct {
 	CreatedAt string `json:"created_at"` // 创建时间
 	UpdatedAt string `json:"updated_at"` // 更新时间
 
-	Cover        string   `json:"cover"`        // 文章封面
-	Title        string   `json:"title"`        // 文章标题
-	Keyword      string   `json:"keyword"`      // 文章标题-关键字
-	Category     string   `json:"category"`     // 文章类别
-	Tags         []string `json:"tags"`         // 文章标签
-	Abstract     string   `json:"abstract"`     // 文章简介
-	Content      string   `json:"content"`      // 文章内容
-	VisibleRange uint     `json:"visibleRange"` // 可见范围 1-"全部可见"/2-"仅我可见"
+	Cover        string   `json:"cover"`         // 文章封面
+	Title        string   `json:"title"`         // 文章标题
+	Keyword      string   `json:"keyword"`       // 文章标题-关键字
+	Category     string   `json:"category"`      // 文章类别
+	Tags         []string `json:"tags"`          // 文章标签
+	Abstract     string   `json:"abstract"`      // 文章简介
+	Content      string   `json:"content"`       // 文章内容
+	VisibleRange uint     `json:"visible_range"` // 可见范围 1-"全部可见"/2-"仅我可见"
+
+	LegacyVisibleRange uint `json:"visibleRange,omitempty"` // 早期文档的可见范围字段，读取时通过 Visibility 与 visible_range 合并
+
+	ContentHTML string             `json:"content_html,omitempty"` // 正文渲染后的 HTML（已做安全过滤）
+	TOC         []markdown.Heading `json:"toc,omitempty"`          // 正文目录
+
+	AuthorID   uint   `json:"author_id"`   // 作者用户ID（0 表示早期未记录作者的文章）
+	AuthorUUID string `json:"author_uuid"` // 作者用户UUID
+
+	Status    consts.ArticleStatus `json:"status"`               // 文章状态 草稿/已发布/定时发布/已归档
+	PublishAt string               `json:"publish_at,omitempty"` // 发布时间（定时发布时为计划发布时间）
 
 	Views    int `json:"views"`    // 浏览量
 	Comments int `json:"comments"` // 评论量
 	Likes    int `json:"likes"`    // 收藏量
+
+	Pinned        bool   `json:"pinned,omitempty"`         // 是否置顶，置顶文章在列表中排在最前
+	PinWeight     int    `json:"pin_weight,omitempty"`     // 置顶权重，越大越靠前
+	PinUntil      string `json:"pin_until,omitempty"`      // 置顶截止时间，为空表示长期置顶
+	Featured      bool   `json:"featured,omitempty"`       // 是否精选，精选文章出现在首页轮播中
+	FeatureWeight int    `json:"feature_weight,omitempty"` // 精选权重，越大越靠前
+	FeatureUntil  string `json:"feature_until,omitempty"`  // 精选截止时间，为空表示长期精选
+}
+
+// Visibility 文章实际的可见范围
+// - 优先取 visible_range；早期文档只有 visibleRange，两者都没有时视为全部可见
+func (a Article) Visibility() uint {
+	if a.VisibleRange != 0 {
+		return a.VisibleRange
+	}
+	if a.LegacyVisibleRange != 0 {
+		return a.LegacyVisibleRange
+	}
+	return VisiblePublic
+}
+
+// IsPrivate 文章是否“仅我可见”，与 PrivateQuery 的条件一致
+func (a Article) IsPrivate() bool {
+	return a.Visibility() == VisiblePrivate
 }
 
 // EsOption 搜索参数
@@ -34,13 +79,78 @@ type EsOption struct {
     IncludeContent bool            // 是否返回 content 字段，默认不返回
 }
 
-// ArticleIndex 文章 ES 索引
+// ArticleIndex 文章 ES 索引别名，读写都通过别名进行
+// - 实际数据存放在带版本号的物理索引中（article_index_v1、article_index_v2……），重建索引时切换别名
 func ArticleIndex() string {
 	return "article_index"
 }
 
+// ArticleIndexVersion 指定版本的文章物理索引名
+func ArticleIndexVersion(version int) string {
+	return fmt.Sprintf("%s_v%d", ArticleIndex(), version)
+}
+
+// ParseArticleIndexVersion 从物理索引名解析版本号，不是带版本号的文章索引时返回 false
+func ParseArticleIndexVersion(index string) (int, bool) {
+	suffix, ok := strings.CutPrefix(index, ArticleIndex()+"_v")
+	if !ok {
+		return 0, false
+	}
+	version, err := strconv.Atoi(suffix)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// PublishedQuery 已发布文章过滤条件
+// - status 缺失的历史文章同样视为已发布
+func PublishedQuery() types.Query {
+	return types.Query{Bool: &types.BoolQuery{
+		Should: []types.Query{
+			{Term: map[string]types.TermQuery{"status": {Value: consts.ArticlePublished}}},
+			{Bool: &types.BoolQuery{MustNot: []types.Query{{Exists: &types.ExistsQuery{Field: "status"}}}}},
+		},
+		MinimumShouldMatch: 1,
+	}}
+}
+
+// VisibleQuery 指定用户可见的文章过滤条件
+// - 已发布且非“仅我可见”的文章所有人可见
+// - 自己的文章（含草稿、仅我可见）作者本人可见；viewerID 为 0 表示游客
+func VisibleQuery(viewerID uint) types.Query {
+	public := types.Query{Bool: &types.BoolQuery{
+		Filter:  []types.Query{PublishedQuery()},
+		MustNot: []types.Query{PrivateQuery()},
+	}}
+	if viewerID == 0 {
+		return public
+	}
+	return types.Query{Bool: &types.BoolQuery{
+		Should: []types.Query{
+			public,
+			{Term: map[string]types.TermQuery{"author_id": {Value: viewerID}}},
+		},
+		MinimumShouldMatch: 1,
+	}}
+}
+
+// PrivateQuery “仅我可见”文章的匹配条件
+// - 早期文档的可见范围写在 visibleRange 字段中，一并匹配
+func PrivateQuery() types.Query {
+	return types.Query{Bool: &types.BoolQuery{
+		Should: []types.Query{
+			{Term: map[string]types.TermQuery{"visible_range": {Value: VisiblePrivate}}},
+			{Term: map[string]types.TermQuery{"visibleRange": {Value: VisiblePrivate}}},
+		},
+		MinimumShouldMatch: 1,
+	}}
+}
+
 // ArticleMapping 文章 Mapping 映射
-func ArticleMapping() *types.TypeMapping {
+// - analyzer/searchAnalyzer 作用于标题、摘要、正文三个全文字段，为空时使用 ES 默认分词器
+func ArticleMapping(analyzer, searchAnalyzer string) *types.TypeMapping {
+	notIndexed := false
 	return &types.TypeMapping{
 		Properties: map[string]types.Property{
 			"created_at": types.DateProperty{
@@ -55,17 +165,42 @@ func ArticleMapping() *types.TypeMapping {
 				}("yyyy-MM-dd HH:mm:ss")},
 
 			"cover":         types.TextProperty{},
-			"title":         types.TextProperty{},
+			"title":         titleProperty(analyzer, searchAnalyzer),
 			"keyword":       types.KeywordProperty{},
 			"category":      types.KeywordProperty{},
 			"tags":          types.KeywordProperty{},
-			"abstract":      types.TextProperty{},
-			"content":       types.TextProperty{},
+			"abstract":      textProperty(analyzer, searchAnalyzer),
+			"content":       textProperty(analyzer, searchAnalyzer),
+			"content_html":  types.TextProperty{Index: &notIndexed},
+			"toc":           types.ObjectProperty{Enabled: &notIndexed},
 			"visible_range": types.KeywordProperty{},
+			"author_id":     types.LongNumberProperty{},
+			"author_uuid":   types.KeywordProperty{},
+			"status":        types.IntegerNumberProperty{},
+			"publish_at": types.DateProperty{
+				NullValue: nil,
+				Format: func(s string) *string {
+					return &s
+				}("yyyy-MM-dd HH:mm:ss")},
 
 			"views":    types.IntegerNumberProperty{},
 			"comments": types.IntegerNumberProperty{},
 			"likes":    types.IntegerNumberProperty{},
+
+			"pinned":     types.BooleanProperty{},
+			"pin_weight": types.IntegerNumberProperty{},
+			"pin_until": types.DateProperty{
+				NullValue: nil,
+				Format: func(s string) *string {
+					return &s
+				}("yyyy-MM-dd HH:mm:ss")},
+			"featured":       types.BooleanProperty{},
+			"feature_weight": types.IntegerNumberProperty{},
+			"feature_until": types.DateProperty{
+				NullValue: nil,
+				Format: func(s string) *string {
+					return &s
+				}("yyyy-MM-dd HH:mm:ss")},
 		},
 	}
 }
@@ -78,23 +213,71 @@ func ArticleMapping() *types.TypeMapping {
    DateProperty（日期类型）
 
    - created_at 、 updated_at ：创建和更新时间
+   - publish_at ：发布时间，定时发布任务据此判断是否到期
+   - pin_until 、 feature_until ：置顶/精选截止时间，到期后由定时任务取消
    - 格式： yyyy-MM-dd HH:mm:ss
    - 支持时间范围查询和排序
 2.
    TextProperty（全文搜索类型）
 
    - cover 、 title 、 abstract 、 content ：封面、标题、摘要、内容
-   - 支持分词和全文搜索
+   - 支持分词和全文搜索，title/abstract/content 的分词器取自配置 es.analyzer / es.search_analyzer
+     （中文推荐 ik_max_word + ik_smart 或 smartcn，插件缺失时回退为 standard）
    - 用户搜索时会匹配这些字段
+   - title.suggest 为 search_as_you_type 子字段，用于搜索框的标题联想（边输入边提示）
 3.
    KeywordProperty（精确匹配类型）
 
    - keyword 、 category ：关键字、分类
+   - author_uuid ：作者UUID
    - tags ：标签数组
    - 不分词，支持精确匹配和聚合统计
 4.
    IntegerNumberProperty（整数类型）
 
    - views 、 comments 、 likes ：浏览量、评论数、点赞数
+   - author_id ：作者用户ID，用于权限校验与“仅我可见”过滤
+   - status ：文章状态（1-草稿/2-已发布/3-定时发布/4-已归档，缺失视为已发布）
+   - pin_weight 、 feature_weight ：置顶/精选权重，越大越靠前
    - 支持数值范围查询和排序
+5.
+   仅存储不索引的字段
+
+   - content_html ：正文渲染后的 HTML，toc ：正文目录
+   - 写入文章时由 Markdown 渲染生成，只随文章详情返回，不参与搜索
+6.
+   BooleanProperty（布尔类型）
+
+   - pinned ：是否置顶，文章列表按置顶、置顶权重排在最前
+   - featured ：是否精选，首页轮播只取精选且有封面的公开文章
+   - 早于该功能创建的索引需执行 --es-reindex 才有这些字段的映射
 */
+
+// textProperty 构建带分词器的全文字段，分词器为空时不设置
+func textProperty(analyzer, searchAnalyzer string) types.TextProperty {
+	property := types.TextProperty{}
+	if analyzer != "" {
+		property.Analyzer = &analyzer
+	}
+	if searchAnalyzer != "" {
+		property.SearchAnalyzer = &searchAnalyzer
+	}
+	return property
+}
+
+// TitleSuggestField 标题联想子字段名，完整路径为 title.suggest
+const TitleSuggestField = "suggest"
+
+// titleProperty 构建标题字段：在全文字段基础上增加 search_as_you_type 子字段 suggest，用于标题联想
+func titleProperty(analyzer, searchAnalyzer string) types.TextProperty {
+	property := textProperty(analyzer, searchAnalyzer)
+	suggest := types.SearchAsYouTypeProperty{}
+	if analyzer != "" {
+		suggest.Analyzer = &analyzer
+	}
+	if searchAnalyzer != "" {
+		suggest.SearchAnalyzer = &searchAnalyzer
+	}
+	property.Fields = map[string]types.Property{TitleSuggestField: suggest}
+	return property
+}
@@ -1,9 +1,9 @@
 package entity
 
-// ArticleLike 文章收藏表
+// ArticleLike 文章收藏表 - (article_id, user_id) 唯一，保证同一用户对同一文章只会收藏一次
 type ArticleLike struct {
 	MODEL            // 基础信息
-	ArticleID string `json:"article_id"` // 文章 ID
-	UserID    uint   `json:"user_id"`    // 用户 ID
+	ArticleID string `json:"article_id" gorm:"type:varchar(64);not null;uniqueIndex:idx_article_user;comment:'文章ID（ES文档ID）'"` // 文章 ID
+	UserID    uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_article_user;index;comment:'用户ID'"`                       // 用户 ID
 	User      User   `json:"-" gorm:"foreignKey:UserID"`
 }
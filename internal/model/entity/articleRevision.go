@@ -0,0 +1,15 @@
+package entity
+
+// ArticleRevision 文章历史版本表 - 每次更新文章时保存更新后的快照（首次更新时先保存更新前的内容作为基线），用于对比与回滚
+type ArticleRevision struct {
+	MODEL
+	ArticleID string   `json:"article_id" gorm:"type:varchar(64);not null;uniqueIndex:idx_article_version;comment:'文章ID（ES文档ID）'"` // 所属文章的 ES 文档 ID
+	Version   uint     `json:"version" gorm:"not null;uniqueIndex:idx_article_version;comment:'版本号（文章内递增）'"`                       // 版本号，从1开始递增
+	Cover     string   `json:"cover" gorm:"type:varchar(512);comment:'封面url'"`                                                     // 封面url
+	Title     string   `json:"title" gorm:"type:varchar(255);not null;comment:'标题'"`                                               // 标题
+	Category  string   `json:"category" gorm:"type:varchar(64);comment:'分类'"`                                                      // 分类
+	Tags      []string `json:"tags" gorm:"serializer:json;type:json;comment:'标签'"`                                                 // 标签
+	Abstract  string   `json:"abstract" gorm:"type:text;comment:'摘要'"`                                                             // 摘要
+	Content   string   `json:"content" gorm:"type:longtext;comment:'文章内容'"`                                                        // 文章内容
+	EditorID  uint     `json:"editor_id" gorm:"type:bigint unsigned;not null;default:0;index;comment:'编辑者ID(0表示未知)'"`              // 编辑者ID
+}
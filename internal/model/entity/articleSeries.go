@@ -0,0 +1,18 @@
+package entity
+
+// ArticleSeries 文章专栏表 - 按顺序组织的一组文章（如分多篇发布的教程），文章顺序见 ArticleSeriesItem
+type ArticleSeries struct {
+	MODEL
+	Title       string `json:"title" gorm:"type:varchar(255);not null;comment:'专栏标题'"`                         // 专栏标题
+	Description string `json:"description" gorm:"type:text;comment:'专栏简介'"`                                    // 专栏简介
+	Cover       string `json:"cover" gorm:"type:varchar(512);comment:'封面url'"`                                 // 封面url
+	AuthorID    uint   `json:"author_id" gorm:"type:bigint unsigned;not null;default:0;index;comment:'创建者ID'"` // 创建者ID
+}
+
+// ArticleSeriesItem 专栏文章表 - 专栏中的文章及其顺序，article_id 唯一，一篇文章最多属于一个专栏
+type ArticleSeriesItem struct {
+	MODEL
+	SeriesID  uint   `json:"series_id" gorm:"not null;index:idx_series_sort;comment:'专栏ID'"`                 // 专栏ID
+	ArticleID string `json:"article_id" gorm:"type:varchar(64);not null;uniqueIndex;comment:'文章ID（ES文档ID）'"` // 文章的 ES 文档 ID
+	Sort      int    `json:"sort" gorm:"not null;default:0;index:idx_series_sort;comment:'在专栏中的顺序（从1开始）'"`   // 在专栏中的顺序
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "personal_blog/internal/model/consts"
+
+// Comment 文章评论表 - 支持楼中楼回复，通过 ParentID/RootID 组织评论树
+type Comment struct {
+	MODEL
+	ArticleID string               `json:"article_id" gorm:"type:varchar(64);not null;index;comment:'文章ID（ES文档ID）'"` // 所属文章的 ES 文档 ID
+	UserID    uint                 `json:"user_id" gorm:"type:bigint unsigned;not null;index;comment:'评论用户ID'"`      // 评论用户ID
+	User      User                 `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`                // 评论用户信息
+	ParentID  uint                 `json:"parent_id" gorm:"not null;default:0;index;comment:'父评论ID(0表示顶级评论)'"`       // 直接回复的评论ID
+	RootID    uint                 `json:"root_id" gorm:"not null;default:0;index;comment:'根评论ID(0表示自身为根评论)'"`       // 所属顶级评论ID，便于整楼查询
+	Content   string               `json:"content" gorm:"type:text;not null;comment:'评论内容'"`                         // 评论内容
+	Status    consts.CommentStatus `json:"status" gorm:"type:tinyint;not null;default:1;index;comment:'评论状态'"`       // 评论状态：0隐藏，1正常，2待审核
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"personal_blog/internal/model/consts"
+	"time"
+)
+
+// EsOutbox ES 同步发件箱表 - 与业务数据在同一事务中写入，由后台任务按顺序把操作应用到 ES
+type EsOutbox struct {
+	MODEL
+	ArticleID   string                `json:"article_id" gorm:"type:varchar(64);not null;index;comment:'文章ID（ES文档ID）'"`    // 目标文章的 ES 文档 ID
+	Op          consts.EsOutboxOp     `json:"op" gorm:"type:tinyint;not null;comment:'操作类型 1-写入 2-更新 3-删除'"`               // 操作类型
+	Payload     string                `json:"payload" gorm:"type:longtext;comment:'文档内容（JSON）'"`                           // 写入/更新的文档内容
+	Status      consts.EsOutboxStatus `json:"status" gorm:"type:tinyint;not null;default:1;index;comment:'状态 1-待同步 2-死信'"` // 状态
+	Attempts    int                   `json:"attempts" gorm:"not null;default:0;comment:'已尝试次数'"`                          // 已尝试次数
+	NextRetryAt time.Time             `json:"next_retry_at" gorm:"type:datetime;not null;index;comment:'下次可执行时间'"`         // 下次可执行时间，也用作处理中的租约
+	LastError   string                `json:"last_error" gorm:"type:text;comment:'最近一次失败原因'"`                              // 最近一次失败原因
+}
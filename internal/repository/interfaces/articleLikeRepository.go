@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ArticleLikeRepository 文章收藏仓储接口
+type ArticleLikeRepository interface {
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// Create 收藏文章；已收藏时不做任何操作，返回是否新增了记录
+	Create(ctx context.Context, tx *gorm.DB, articleID string, userID uint) (bool, error)
+	// Delete 取消收藏，返回是否删除了记录
+	Delete(ctx context.Context, tx *gorm.DB, articleID string, userID uint) (bool, error)
+	// Exists 判断用户是否已收藏文章
+	Exists(ctx context.Context, articleID string, userID uint) (bool, error)
+	// ListArticleIDsByUser 查询用户收藏的全部文章ID（按收藏时间倒序）
+	ListArticleIDsByUser(ctx context.Context, userID uint) ([]string, error)
+	// DeleteByArticleID 删除文章的全部收藏记录
+	DeleteByArticleID(ctx context.Context, tx *gorm.DB, articleID string) error
+}
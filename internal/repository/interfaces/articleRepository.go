@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"personal_blog/internal/model/entity"
 
 	"gorm.io/gorm"
 )
@@ -16,4 +17,16 @@ type ArticleRepository interface {
 	AddOrIncTag(ctx context.Context, tx *gorm.DB, tags []string) error
 	// DecOrDeleteTag 将标签计数-1；若减少前计数为1则删除该标签
 	DecOrDeleteTag(ctx context.Context, tx *gorm.DB, tags []string) error
+	// ListCategories 查询分类及计数；byName 为 true 时按名称升序，否则按计数倒序；limit<=0 表示不限制
+	ListCategories(ctx context.Context, byName bool, limit int) ([]entity.ArticleCategory, error)
+	// ListTags 查询标签及计数；byName 为 true 时按名称升序，否则按计数倒序；limit<=0 表示不限制
+	ListTags(ctx context.Context, byName bool, limit int) ([]entity.ArticleTag, error)
+	// RenameCategory 将分类 from 重命名为 to；to 已存在时两者计数合并
+	RenameCategory(ctx context.Context, tx *gorm.DB, from, to string) error
+	// MergeTags 删除被合并的标签 sources，并将目标标签 target 的计数设置为 number（为0时删除）
+	MergeTags(ctx context.Context, tx *gorm.DB, sources []string, target string, number int) error
+	// SetCategoryNumber 将分类计数设置为 number，不存在时创建，number<=0 时删除
+	SetCategoryNumber(ctx context.Context, tx *gorm.DB, category string, number int) error
+	// SetTagNumber 将标签计数设置为 number，不存在时创建，number<=0 时删除
+	SetTagNumber(ctx context.Context, tx *gorm.DB, tag string, number int) error
 }
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"personal_blog/internal/model/entity"
+
+	"gorm.io/gorm"
+)
+
+// ArticleRevisionRepository 文章历史版本仓储接口
+type ArticleRevisionRepository interface {
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// Create 保存一个新版本，版本号在当前文章最大版本号基础上+1
+	Create(ctx context.Context, tx *gorm.DB, revision *entity.ArticleRevision) error
+	// GetByID 根据ID查询版本，不存在时返回 nil
+	GetByID(ctx context.Context, id uint) (*entity.ArticleRevision, error)
+	// ListByArticleID 分页查询文章的历史版本（不含内容，按版本号倒序）
+	ListByArticleID(ctx context.Context, articleID string, page, pageSize int) ([]*entity.ArticleRevision, int64, error)
+	// CountByArticleID 统计文章的历史版本数量
+	CountByArticleID(ctx context.Context, tx *gorm.DB, articleID string) (int64, error)
+	// DeleteByArticleID 删除文章的全部历史版本
+	DeleteByArticleID(ctx context.Context, tx *gorm.DB, articleID string) error
+}
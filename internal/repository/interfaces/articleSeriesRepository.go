@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"personal_blog/internal/model/entity"
+
+	"gorm.io/gorm"
+)
+
+// ArticleSeriesRepository 文章专栏仓储接口
+type ArticleSeriesRepository interface {
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// Create 创建专栏
+	Create(ctx context.Context, tx *gorm.DB, series *entity.ArticleSeries) error
+	// Update 更新专栏的标题、简介与封面
+	Update(ctx context.Context, tx *gorm.DB, series *entity.ArticleSeries) error
+	// Delete 删除专栏及其文章顺序（文章本身不受影响）
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+	// GetByID 根据ID查询专栏，不存在时返回 nil
+	GetByID(ctx context.Context, id uint) (*entity.ArticleSeries, error)
+	// List 分页查询专栏（按创建时间倒序）
+	List(ctx context.Context, page, pageSize int) ([]*entity.ArticleSeries, int64, error)
+	// ListArticleIDs 按顺序返回专栏中的文章ID
+	ListArticleIDs(ctx context.Context, seriesID uint) ([]string, error)
+	// ReplaceArticles 以 articleIDs 的顺序重写专栏中的文章
+	ReplaceArticles(ctx context.Context, tx *gorm.DB, seriesID uint, articleIDs []string) error
+	// GetItemByArticleID 查询文章所在专栏的记录，文章不属于任何专栏时返回 nil
+	GetItemByArticleID(ctx context.Context, articleID string) (*entity.ArticleSeriesItem, error)
+	// ListItemsByArticleIDs 批量查询文章所在专栏的记录
+	ListItemsByArticleIDs(ctx context.Context, tx *gorm.DB, articleIDs []string) ([]*entity.ArticleSeriesItem, error)
+	// DeleteItemByArticleID 将文章移出所在专栏
+	DeleteItemByArticleID(ctx context.Context, tx *gorm.DB, articleID string) error
+}
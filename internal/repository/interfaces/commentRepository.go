@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"personal_blog/internal/model/entity"
+
+	"gorm.io/gorm"
+)
+
+// CommentRepository 评论仓储接口
+type CommentRepository interface {
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// Create 创建评论
+	Create(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error
+	// GetByID 根据ID查询评论，不存在时返回 nil
+	GetByID(ctx context.Context, id uint) (*entity.Comment, error)
+	// ListRoots 分页查询文章下的正常顶级评论（按时间倒序）
+	ListRoots(ctx context.Context, articleID string, page, pageSize int) ([]*entity.Comment, int64, error)
+	// ListReplies 查询指定顶级评论下的全部正常回复（按时间正序）
+	ListReplies(ctx context.Context, rootIDs []uint) ([]*entity.Comment, error)
+	// DeleteWithReplies 删除评论及其所有子回复，返回被删除的正常评论数量
+	DeleteWithReplies(ctx context.Context, tx *gorm.DB, id uint) (int64, error)
+	// DeleteByArticleID 删除文章下的全部评论
+	DeleteByArticleID(ctx context.Context, tx *gorm.DB, articleID string) error
+}
@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EsOutboxRepository ES 同步发件箱仓储接口
+type EsOutboxRepository interface {
+	Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// Create 在业务事务中写入一条待同步操作
+	Create(ctx context.Context, tx *gorm.DB, outbox *entity.EsOutbox) error
+	// ListDue 查询已到执行时间的待同步操作（按ID升序），只返回每篇文章最早的一条未完成操作
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.EsOutbox, error)
+	// ListArticleIDs 查询仍有未完成操作（含死信）的文章ID
+	ListArticleIDs(ctx context.Context) ([]string, error)
+	// ListByArticleID 查询文章全部未完成的操作（含死信，按ID升序）
+	ListByArticleID(ctx context.Context, articleID string) ([]*entity.EsOutbox, error)
+	// Claim 抢占一条已到执行时间的待同步操作，成功后在 leaseUntil 之前其他执行者无法再抢占
+	Claim(ctx context.Context, id uint, now, leaseUntil time.Time) (bool, error)
+	// Delete 删除同步成功的操作
+	Delete(ctx context.Context, id uint) error
+	// MarkFailed 记录一次失败：更新状态、尝试次数、下次执行时间与失败原因
+	MarkFailed(ctx context.Context, id uint, status consts.EsOutboxStatus, attempts int, nextRetryAt time.Time, lastError string) error
+	// ListByStatus 按状态分页查询（按ID倒序，不含文档内容）
+	ListByStatus(ctx context.Context, status consts.EsOutboxStatus, page, pageSize int) ([]*entity.EsOutbox, int64, error)
+	// Requeue 将死信重新放回待同步队列，返回实际恢复的条数
+	Requeue(ctx context.Context, ids []uint, now time.Time) (int64, error)
+}
@@ -15,4 +15,6 @@ type ImageRepository interface {
     ListByUser(ctx context.Context, userID uint, page, pageSize int) ([]*entity.Image, int64, error)
     // DeleteByID 根据ID删除图片（软删除）
     DeleteByID(ctx context.Context, id uint) error
+    // ListByURLs 根据访问URL批量查询图片
+    ListByURLs(ctx context.Context, urls []string) ([]*entity.Image, error)
 }
\ No newline at end of file
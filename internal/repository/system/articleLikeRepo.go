@@ -0,0 +1,92 @@
+package system
+
+import (
+	"context"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository/interfaces"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleLikeGormRepository 文章收藏仓储GORM实现
+type ArticleLikeGormRepository struct {
+	db *gorm.DB
+}
+
+// NewArticleLikeRepository 创建文章收藏仓储实例
+func NewArticleLikeRepository(db *gorm.DB) interfaces.ArticleLikeRepository {
+	return &ArticleLikeGormRepository{db: db}
+}
+
+// Create 收藏文章；依赖 (article_id, user_id) 唯一索引，重复收藏不会产生新记录
+func (r *ArticleLikeGormRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+	userID uint,
+) (bool, error) {
+	res := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.ArticleLike{ArticleID: articleID, UserID: userID})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// Delete 取消收藏（物理删除，避免软删除记录占用唯一索引）
+func (r *ArticleLikeGormRepository) Delete(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+	userID uint,
+) (bool, error) {
+	res := tx.WithContext(ctx).Unscoped().
+		Where("article_id = ? AND user_id = ?", articleID, userID).
+		Delete(&entity.ArticleLike{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// Exists 判断用户是否已收藏文章
+func (r *ArticleLikeGormRepository) Exists(
+	ctx context.Context,
+	articleID string,
+	userID uint,
+) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.ArticleLike{}).
+		Where("article_id = ? AND user_id = ?", articleID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListArticleIDsByUser 查询用户收藏的全部文章ID（按收藏时间倒序）
+func (r *ArticleLikeGormRepository) ListArticleIDsByUser(
+	ctx context.Context,
+	userID uint,
+) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&entity.ArticleLike{}).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+// DeleteByArticleID 删除文章的全部收藏记录
+func (r *ArticleLikeGormRepository) DeleteByArticleID(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+) error {
+	return tx.WithContext(ctx).Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleLike{}).Error
+}
+
+// Transaction 事物统一处理，用以保证原子性
+func (r *ArticleLikeGormRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
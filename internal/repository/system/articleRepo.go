@@ -118,6 +118,131 @@ func (r *ArticleGormRepository) DecOrDeleteTag(
     return nil
 }
 
+// ListCategories 查询分类及计数；byName 为 true 时按名称升序，否则按计数倒序；limit<=0 表示不限制
+func (r *ArticleGormRepository) ListCategories(
+	ctx context.Context,
+	byName bool,
+	limit int,
+) ([]entity.ArticleCategory, error) {
+	var list []entity.ArticleCategory
+	db := r.db.WithContext(ctx).Model(&entity.ArticleCategory{})
+	if byName {
+		db = db.Order("category asc")
+	} else {
+		db = db.Order("number desc").Order("category asc")
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	if err := db.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ListTags 查询标签及计数；byName 为 true 时按名称升序，否则按计数倒序；limit<=0 表示不限制
+func (r *ArticleGormRepository) ListTags(
+	ctx context.Context,
+	byName bool,
+	limit int,
+) ([]entity.ArticleTag, error) {
+	var list []entity.ArticleTag
+	db := r.db.WithContext(ctx).Model(&entity.ArticleTag{})
+	if byName {
+		db = db.Order("tag asc")
+	} else {
+		db = db.Order("number desc").Order("tag asc")
+	}
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	if err := db.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// RenameCategory 将分类 from 重命名为 to；to 已存在时两者计数合并
+func (r *ArticleGormRepository) RenameCategory(
+	ctx context.Context,
+	tx *gorm.DB,
+	from,
+	to string,
+) error {
+	// 1、原分类没有计数记录时无需调整
+	var src entity.ArticleCategory
+	if err := tx.WithContext(ctx).Where("category = ?", from).First(&src).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	// 2、目标分类不存在则创建，存在则累加计数
+	var dst entity.ArticleCategory
+	err := tx.WithContext(ctx).Where("category = ?", to).First(&dst).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if err = tx.WithContext(ctx).Create(&entity.ArticleCategory{Category: to, Number: src.Number}).Error; err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err = tx.WithContext(ctx).
+			Model(&entity.ArticleCategory{}).
+			Where("category = ?", to).
+			Update("number", gorm.Expr("number + ?", src.Number)).Error; err != nil {
+			return err
+		}
+	}
+	// 3、删除原分类
+	return tx.WithContext(ctx).Where("category = ?", from).Delete(&entity.ArticleCategory{}).Error
+}
+
+// MergeTags 删除被合并的标签 sources，并将目标标签 target 的计数设置为 number（为0时删除）
+func (r *ArticleGormRepository) MergeTags(
+	ctx context.Context,
+	tx *gorm.DB,
+	sources []string,
+	target string,
+	number int,
+) error {
+	// 1、删除被合并的标签
+	if len(sources) > 0 {
+		if err := tx.WithContext(ctx).Where("tag IN ?", sources).Delete(&entity.ArticleTag{}).Error; err != nil {
+			return err
+		}
+	}
+	// 2、重置目标标签计数
+	return r.SetTagNumber(ctx, tx, target, number)
+}
+
+// SetCategoryNumber 将分类计数设置为 number，不存在时创建，number<=0 时删除
+func (r *ArticleGormRepository) SetCategoryNumber(
+	ctx context.Context,
+	tx *gorm.DB,
+	category string,
+	number int,
+) error {
+	if number <= 0 {
+		return tx.WithContext(ctx).Where("category = ?", category).Delete(&entity.ArticleCategory{}).Error
+	}
+	return tx.WithContext(ctx).Save(&entity.ArticleCategory{Category: category, Number: number}).Error
+}
+
+// SetTagNumber 将标签计数设置为 number，不存在时创建，number<=0 时删除
+func (r *ArticleGormRepository) SetTagNumber(
+	ctx context.Context,
+	tx *gorm.DB,
+	tag string,
+	number int,
+) error {
+	if number <= 0 {
+		return tx.WithContext(ctx).Where("tag = ?", tag).Delete(&entity.ArticleTag{}).Error
+	}
+	return tx.WithContext(ctx).Save(&entity.ArticleTag{Tag: tag, Number: number}).Error
+}
+
 // Transaction 事物统一处理，用以保证原子性
 func (r *ArticleGormRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	return r.db.WithContext(ctx).Transaction(fn)
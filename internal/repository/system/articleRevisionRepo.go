@@ -0,0 +1,103 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository/interfaces"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleRevisionGormRepository 文章历史版本仓储GORM实现
+type ArticleRevisionGormRepository struct {
+	db *gorm.DB
+}
+
+// NewArticleRevisionRepository 创建文章历史版本仓储实例
+func NewArticleRevisionRepository(db *gorm.DB) interfaces.ArticleRevisionRepository {
+	return &ArticleRevisionGormRepository{db: db}
+}
+
+// Create 保存一个新版本，版本号在当前文章最大版本号基础上+1
+func (r *ArticleRevisionGormRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	revision *entity.ArticleRevision,
+) error {
+	// 1、锁定该文章的版本记录，获取当前最大版本号
+	var maxVersion uint
+	if err := tx.WithContext(ctx).Unscoped().Model(&entity.ArticleRevision{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("article_id = ?", revision.ArticleID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&maxVersion).Error; err != nil {
+		return err
+	}
+	// 2、保存新版本
+	revision.Version = maxVersion + 1
+	return tx.WithContext(ctx).Create(revision).Error
+}
+
+// GetByID 根据ID查询版本，不存在时返回 nil
+func (r *ArticleRevisionGormRepository) GetByID(ctx context.Context, id uint) (*entity.ArticleRevision, error) {
+	var rev entity.ArticleRevision
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// ListByArticleID 分页查询文章的历史版本（不含内容，按版本号倒序）
+func (r *ArticleRevisionGormRepository) ListByArticleID(
+	ctx context.Context,
+	articleID string,
+	page, pageSize int,
+) ([]*entity.ArticleRevision, int64, error) {
+	var revisions []*entity.ArticleRevision
+	var total int64
+	q := r.db.WithContext(ctx).Model(&entity.ArticleRevision{}).Where("article_id = ?", articleID)
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	if err := q.Omit("content").
+		Offset(offset).Limit(pageSize).
+		Order("version DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+// CountByArticleID 统计文章的历史版本数量
+func (r *ArticleRevisionGormRepository) CountByArticleID(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+) (int64, error) {
+	var total int64
+	err := tx.WithContext(ctx).Model(&entity.ArticleRevision{}).
+		Where("article_id = ?", articleID).
+		Count(&total).Error
+	return total, err
+}
+
+// DeleteByArticleID 删除文章的全部历史版本
+func (r *ArticleRevisionGormRepository) DeleteByArticleID(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+) error {
+	return tx.WithContext(ctx).Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleRevision{}).Error
+}
+
+// Transaction 事物统一处理，用以保证原子性
+func (r *ArticleRevisionGormRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
@@ -0,0 +1,153 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// ArticleSeriesGormRepository 文章专栏仓储GORM实现
+type ArticleSeriesGormRepository struct {
+	db *gorm.DB
+}
+
+// NewArticleSeriesRepository 创建文章专栏仓储实例
+func NewArticleSeriesRepository(db *gorm.DB) interfaces.ArticleSeriesRepository {
+	return &ArticleSeriesGormRepository{db: db}
+}
+
+// Create 创建专栏
+func (r *ArticleSeriesGormRepository) Create(ctx context.Context, tx *gorm.DB, series *entity.ArticleSeries) error {
+	return tx.WithContext(ctx).Create(series).Error
+}
+
+// Update 更新专栏的标题、简介与封面
+func (r *ArticleSeriesGormRepository) Update(ctx context.Context, tx *gorm.DB, series *entity.ArticleSeries) error {
+	return tx.WithContext(ctx).Model(series).
+		Select("title", "description", "cover").
+		Updates(series).Error
+}
+
+// Delete 删除专栏及其文章顺序（文章本身不受影响）
+func (r *ArticleSeriesGormRepository) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	// 1、删除文章顺序（物理删除，文章可以重新加入其他专栏）
+	if err := tx.WithContext(ctx).Unscoped().
+		Where("series_id = ?", id).
+		Delete(&entity.ArticleSeriesItem{}).Error; err != nil {
+		return err
+	}
+	// 2、删除专栏
+	return tx.WithContext(ctx).Delete(&entity.ArticleSeries{}, id).Error
+}
+
+// GetByID 根据ID查询专栏，不存在时返回 nil
+func (r *ArticleSeriesGormRepository) GetByID(ctx context.Context, id uint) (*entity.ArticleSeries, error) {
+	var series entity.ArticleSeries
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// List 分页查询专栏（按创建时间倒序）
+func (r *ArticleSeriesGormRepository) List(
+	ctx context.Context,
+	page, pageSize int,
+) ([]*entity.ArticleSeries, int64, error) {
+	var list []*entity.ArticleSeries
+	var total int64
+	q := r.db.WithContext(ctx).Model(&entity.ArticleSeries{})
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	if err := q.Offset(offset).Limit(pageSize).Order("id DESC").Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// ListArticleIDs 按顺序返回专栏中的文章ID
+func (r *ArticleSeriesGormRepository) ListArticleIDs(ctx context.Context, seriesID uint) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&entity.ArticleSeriesItem{}).
+		Where("series_id = ?", seriesID).
+		Order("sort ASC").
+		Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+// ReplaceArticles 以 articleIDs 的顺序重写专栏中的文章
+func (r *ArticleSeriesGormRepository) ReplaceArticles(
+	ctx context.Context,
+	tx *gorm.DB,
+	seriesID uint,
+	articleIDs []string,
+) error {
+	// 1、清空原有顺序
+	if err := tx.WithContext(ctx).Unscoped().
+		Where("series_id = ?", seriesID).
+		Delete(&entity.ArticleSeriesItem{}).Error; err != nil {
+		return err
+	}
+	if len(articleIDs) == 0 {
+		return nil
+	}
+	// 2、按新顺序写入
+	items := make([]entity.ArticleSeriesItem, 0, len(articleIDs))
+	for i, id := range articleIDs {
+		items = append(items, entity.ArticleSeriesItem{SeriesID: seriesID, ArticleID: id, Sort: i + 1})
+	}
+	return tx.WithContext(ctx).Create(&items).Error
+}
+
+// GetItemByArticleID 查询文章所在专栏的记录，文章不属于任何专栏时返回 nil
+func (r *ArticleSeriesGormRepository) GetItemByArticleID(
+	ctx context.Context,
+	articleID string,
+) (*entity.ArticleSeriesItem, error) {
+	var item entity.ArticleSeriesItem
+	err := r.db.WithContext(ctx).Where("article_id = ?", articleID).First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListItemsByArticleIDs 批量查询文章所在专栏的记录
+func (r *ArticleSeriesGormRepository) ListItemsByArticleIDs(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleIDs []string,
+) ([]*entity.ArticleSeriesItem, error) {
+	var items []*entity.ArticleSeriesItem
+	if len(articleIDs) == 0 {
+		return items, nil
+	}
+	err := tx.WithContext(ctx).Where("article_id IN ?", articleIDs).Find(&items).Error
+	return items, err
+}
+
+// DeleteItemByArticleID 将文章移出所在专栏
+func (r *ArticleSeriesGormRepository) DeleteItemByArticleID(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+) error {
+	return tx.WithContext(ctx).Unscoped().Where("article_id = ?", articleID).Delete(&entity.ArticleSeriesItem{}).Error
+}
+
+// Transaction 事物统一处理，用以保证原子性
+func (r *ArticleSeriesGormRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
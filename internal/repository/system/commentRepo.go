@@ -0,0 +1,130 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository/interfaces"
+
+	"gorm.io/gorm"
+)
+
+// CommentGormRepository 评论仓储GORM实现
+type CommentGormRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository 创建评论仓储实例
+func NewCommentRepository(db *gorm.DB) interfaces.CommentRepository {
+	return &CommentGormRepository{db: db}
+}
+
+// Create 创建评论
+func (r *CommentGormRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	comment *entity.Comment,
+) error {
+	return tx.WithContext(ctx).Create(comment).Error
+}
+
+// GetByID 根据ID查询评论，不存在时返回 nil
+func (r *CommentGormRepository) GetByID(ctx context.Context, id uint) (*entity.Comment, error) {
+	var c entity.Comment
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ListRoots 分页查询文章下的正常顶级评论（按时间倒序）
+func (r *CommentGormRepository) ListRoots(
+	ctx context.Context,
+	articleID string,
+	page, pageSize int,
+) ([]*entity.Comment, int64, error) {
+	var comments []*entity.Comment
+	var total int64
+	q := r.db.WithContext(ctx).Model(&entity.Comment{}).
+		Where("article_id = ? AND parent_id = 0 AND status = ?", articleID, consts.CommentNormal)
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	if err := q.Preload("User").
+		Offset(offset).Limit(pageSize).
+		Order("id DESC").
+		Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// ListReplies 查询指定顶级评论下的全部正常回复（按时间正序）
+func (r *CommentGormRepository) ListReplies(
+	ctx context.Context,
+	rootIDs []uint,
+) ([]*entity.Comment, error) {
+	var replies []*entity.Comment
+	if len(rootIDs) == 0 {
+		return replies, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("root_id IN ? AND status = ?", rootIDs, consts.CommentNormal).
+		Order("id ASC").
+		Find(&replies).Error
+	return replies, err
+}
+
+// DeleteWithReplies 删除评论及其所有子回复，返回被删除的正常评论数量
+func (r *CommentGormRepository) DeleteWithReplies(
+	ctx context.Context,
+	tx *gorm.DB,
+	id uint,
+) (int64, error) {
+	// 1、逐层收集子回复ID（广度优先）
+	ids := []uint{id}
+	frontier := []uint{id}
+	for len(frontier) > 0 {
+		var children []uint
+		if err := tx.WithContext(ctx).Model(&entity.Comment{}).
+			Where("parent_id IN ?", frontier).
+			Pluck("id", &children).Error; err != nil {
+			return 0, err
+		}
+		ids = append(ids, children...)
+		frontier = children
+	}
+	// 2、统计其中的正常评论数，用于同步文章评论计数
+	var normal int64
+	if err := tx.WithContext(ctx).Model(&entity.Comment{}).
+		Where("id IN ? AND status = ?", ids, consts.CommentNormal).
+		Count(&normal).Error; err != nil {
+		return 0, err
+	}
+	// 3、删除（软删除）
+	if err := tx.WithContext(ctx).Where("id IN ?", ids).Delete(&entity.Comment{}).Error; err != nil {
+		return 0, err
+	}
+	return normal, nil
+}
+
+// DeleteByArticleID 删除文章下的全部评论
+func (r *CommentGormRepository) DeleteByArticleID(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+) error {
+	return tx.WithContext(ctx).Where("article_id = ?", articleID).Delete(&entity.Comment{}).Error
+}
+
+// Transaction 事物统一处理，用以保证原子性
+func (r *CommentGormRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
@@ -0,0 +1,153 @@
+package system
+
+import (
+	"context"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository/interfaces"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EsOutboxGormRepository ES 同步发件箱仓储GORM实现
+type EsOutboxGormRepository struct {
+	db *gorm.DB
+}
+
+// NewEsOutboxRepository 创建 ES 同步发件箱仓储实例
+func NewEsOutboxRepository(db *gorm.DB) interfaces.EsOutboxRepository {
+	return &EsOutboxGormRepository{db: db}
+}
+
+// Create 在业务事务中写入一条待同步操作
+func (r *EsOutboxGormRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	outbox *entity.EsOutbox,
+) error {
+	return tx.WithContext(ctx).Create(outbox).Error
+}
+
+// ListDue 查询已到执行时间的待同步操作（按ID升序）
+// - 只返回每篇文章最早的一条未完成操作：前一条操作未成功（等待重试或已成为死信）时，后续操作不会被取出
+func (r *EsOutboxGormRepository) ListDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*entity.EsOutbox, error) {
+	var list []*entity.EsOutbox
+	heads := r.db.Model(&entity.EsOutbox{}).Select("MIN(id)").Group("article_id")
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", heads).
+		Where("status = ? AND next_retry_at <= ?", consts.EsOutboxPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// ListByArticleID 查询文章全部未完成的操作（含死信，按ID升序）
+func (r *EsOutboxGormRepository) ListByArticleID(
+	ctx context.Context,
+	articleID string,
+) ([]*entity.EsOutbox, error) {
+	var list []*entity.EsOutbox
+	err := r.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Order("id ASC").
+		Find(&list).Error
+	return list, err
+}
+
+// ListArticleIDs 查询仍有未完成操作（含死信）的文章ID
+func (r *EsOutboxGormRepository) ListArticleIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&entity.EsOutbox{}).
+		Distinct("article_id").
+		Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+// Claim 抢占一条已到执行时间的待同步操作，成功后在 leaseUntil 之前其他执行者无法再抢占
+func (r *EsOutboxGormRepository) Claim(
+	ctx context.Context,
+	id uint,
+	now,
+	leaseUntil time.Time,
+) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&entity.EsOutbox{}).
+		Where("id = ? AND status = ? AND next_retry_at <= ?", id, consts.EsOutboxPending, now).
+		Update("next_retry_at", leaseUntil)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// Delete 删除同步成功的操作
+func (r *EsOutboxGormRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&entity.EsOutbox{}, id).Error
+}
+
+// MarkFailed 记录一次失败：更新状态、尝试次数、下次执行时间与失败原因
+func (r *EsOutboxGormRepository) MarkFailed(
+	ctx context.Context,
+	id uint,
+	status consts.EsOutboxStatus,
+	attempts int,
+	nextRetryAt time.Time,
+	lastError string,
+) error {
+	return r.db.WithContext(ctx).Model(&entity.EsOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":        status,
+			"attempts":      attempts,
+			"next_retry_at": nextRetryAt,
+			"last_error":    lastError,
+		}).Error
+}
+
+// ListByStatus 按状态分页查询（按ID倒序，不含文档内容）
+func (r *EsOutboxGormRepository) ListByStatus(
+	ctx context.Context,
+	status consts.EsOutboxStatus,
+	page, pageSize int,
+) ([]*entity.EsOutbox, int64, error) {
+	var list []*entity.EsOutbox
+	var total int64
+	q := r.db.WithContext(ctx).Model(&entity.EsOutbox{}).Where("status = ?", status)
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	if err := q.Omit("payload").
+		Offset(offset).Limit(pageSize).
+		Order("id DESC").
+		Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// Requeue 将死信重新放回待同步队列，返回实际恢复的条数
+func (r *EsOutboxGormRepository) Requeue(
+	ctx context.Context,
+	ids []uint,
+	now time.Time,
+) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&entity.EsOutbox{}).
+		Where("id IN ? AND status = ?", ids, consts.EsOutboxDead).
+		Updates(map[string]any{
+			"status":        consts.EsOutboxPending,
+			"attempts":      0,
+			"next_retry_at": now,
+		})
+	return res.RowsAffected, res.Error
+}
+
+// Transaction 事物统一处理，用以保证原子性
+func (r *EsOutboxGormRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
@@ -54,4 +54,16 @@ func (r *ImageGormRepository) ListByUser(ctx context.Context, userID uint, page,
 // DeleteByID 根据ID删除图片（软删除）
 func (r *ImageGormRepository) DeleteByID(ctx context.Context, id uint) error {
     return r.db.WithContext(ctx).Delete(&entity.Image{}, id).Error
-}
\ No newline at end of file
+}
+
+// ListByURLs 根据访问URL批量查询图片
+func (r *ImageGormRepository) ListByURLs(ctx context.Context, urls []string) ([]*entity.Image, error) {
+    var imgs []*entity.Image
+    if len(urls) == 0 {
+        return imgs, nil
+    }
+    if err := r.db.WithContext(ctx).Where("url IN ?", urls).Find(&imgs).Error; err != nil {
+        return nil, err
+    }
+    return imgs, nil
+}
@@ -15,6 +15,11 @@ type Supplier interface {
     GetAPIRepository() interfaces.APIRepository
     GetImageRepository() interfaces.ImageRepository
     GetArticleRepository() interfaces.ArticleRepository
+    GetCommentRepository() interfaces.CommentRepository
+    GetArticleLikeRepository() interfaces.ArticleLikeRepository
+    GetArticleRevisionRepository() interfaces.ArticleRevisionRepository
+    GetEsOutboxRepository() interfaces.EsOutboxRepository
+    GetArticleSeriesRepository() interfaces.ArticleSeriesRepository
 }
 
 // SetUp 工厂函数，统一管理 - 现在支持配置驱动
@@ -26,6 +31,11 @@ func SetUp(factoryConfig *adapter.FactoryConfig) Supplier {
     var apiRepo interfaces.APIRepository
     var imageRepo interfaces.ImageRepository
     var articleRepo interfaces.ArticleRepository
+    var commentRepo interfaces.CommentRepository
+    var articleLikeRepo interfaces.ArticleLikeRepository
+    var articleRevisionRepo interfaces.ArticleRevisionRepository
+    var esOutboxRepo interfaces.EsOutboxRepository
+    var articleSeriesRepo interfaces.ArticleSeriesRepository
 
 	switch factoryConfig.DatabaseType {
 	case adapter.MySQL:
@@ -37,6 +47,11 @@ func SetUp(factoryConfig *adapter.FactoryConfig) Supplier {
             apiRepo = NewAPIRepository(db)
             imageRepo = NewImageRepository(db)
             articleRepo = NewArticleRepository(db)
+            commentRepo = NewCommentRepository(db)
+            articleLikeRepo = NewArticleLikeRepository(db)
+            articleRevisionRepo = NewArticleRevisionRepository(db)
+            esOutboxRepo = NewEsOutboxRepository(db)
+            articleSeriesRepo = NewArticleSeriesRepository(db)
         }
 	case adapter.MongoDB:
 		// 未来可以添加Mongo	DB实现
@@ -54,6 +69,11 @@ func SetUp(factoryConfig *adapter.FactoryConfig) Supplier {
             apiRepo = NewAPIRepository(db)
             imageRepo = NewImageRepository(db)
             articleRepo = NewArticleRepository(db)
+            commentRepo = NewCommentRepository(db)
+            articleLikeRepo = NewArticleLikeRepository(db)
+            articleRevisionRepo = NewArticleRevisionRepository(db)
+            esOutboxRepo = NewEsOutboxRepository(db)
+            articleSeriesRepo = NewArticleSeriesRepository(db)
         }
     }
     return &RepositorySupplier{
@@ -64,5 +84,18 @@ func SetUp(factoryConfig *adapter.FactoryConfig) Supplier {
         apiRepository:  apiRepo,
         imageRepository: imageRepo,
         articleRepository: articleRepo,
+        commentRepository: commentRepo,
+        articleLikeRepository: articleLikeRepo,
+        articleRevisionRepository: articleRevisionRepo,
+        esOutboxRepository: esOutboxRepo,
+        articleSeriesRepository: articleSeriesRepo,
     }
 }
+
+func (r *RepositorySupplier) GetEsOutboxRepository() interfaces.EsOutboxRepository {
+    return r.esOutboxRepository
+}
+
+func (r *RepositorySupplier) GetArticleSeriesRepository() interfaces.ArticleSeriesRepository {
+    return r.articleSeriesRepository
+}
@@ -10,6 +10,11 @@ type RepositorySupplier struct {
     apiRepository  interfaces.APIRepository
     imageRepository interfaces.ImageRepository
     articleRepository interfaces.ArticleRepository
+    commentRepository interfaces.CommentRepository
+    articleLikeRepository interfaces.ArticleLikeRepository
+    articleRevisionRepository interfaces.ArticleRevisionRepository
+    esOutboxRepository interfaces.EsOutboxRepository
+    articleSeriesRepository interfaces.ArticleSeriesRepository
 }
 
 func (r *RepositorySupplier) GetUserRepository() interfaces.UserRepository {
@@ -39,3 +44,15 @@ func (r *RepositorySupplier) GetImageRepository() interfaces.ImageRepository {
 func (r *RepositorySupplier) GetArticleRepository() interfaces.ArticleRepository {
     return r.articleRepository
 }
+
+func (r *RepositorySupplier) GetCommentRepository() interfaces.CommentRepository {
+    return r.commentRepository
+}
+
+func (r *RepositorySupplier) GetArticleLikeRepository() interfaces.ArticleLikeRepository {
+    return r.articleLikeRepository
+}
+
+func (r *RepositorySupplier) GetArticleRevisionRepository() interfaces.ArticleRevisionRepository {
+    return r.articleRevisionRepository
+}
@@ -30,8 +30,8 @@ func InitRouter() *gin.Engine {
 	mountStatic(Router)
 	// 配置并挂载会话中间件
 	attachSession(Router)
-	// 超时中间间
-	Router.Use(middleware.TimeoutMiddleware(30 * time.Second))
+	// 超时中间间（文章导出为流式下载，耗时与文章数量相关，由处理函数自行控制超时）
+	Router.Use(middleware.TimeoutMiddleware(30*time.Second, "/article/export"))
 
 	systemRouter := GroupApp.System
 
@@ -43,6 +43,12 @@ func InitRouter() *gin.Engine {
 		systemRouter.InitBaseRouter(PublicGroup)
 		// 用户路由
 		systemRouter.InitUserRouter(PublicGroup)
+		// 博客公开只读接口（文章、分类、标签）
+		systemRouter.InitPublicRouter(PublicGroup)
+		// RSS/Atom 订阅源
+		systemRouter.InitFeedRouter(PublicGroup)
+		// 站点地图与 robots.txt
+		systemRouter.InitSeoRouter(PublicGroup)
 		// todo 登录、注册、健康检测.
 	}
 
@@ -61,6 +67,12 @@ func InitRouter() *gin.Engine {
 		// 图片管理
 		systemRouter.InitImageRouter(BusinessGroup)
 		systemRouter.InitArticleRouter(BusinessGroup)
+		systemRouter.InitCommentRouter(BusinessGroup)
+		systemRouter.InitArticleLikeRouter(BusinessGroup)
+		systemRouter.InitArticleRevisionRouter(BusinessGroup)
+		systemRouter.InitArticleSeriesRouter(BusinessGroup)
+		systemRouter.InitArticleTaxonomyRouter(BusinessGroup)
+		systemRouter.InitEsOutboxRouter(BusinessGroup)
 		// 博客相关路由
 
 	}
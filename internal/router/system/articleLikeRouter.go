@@ -0,0 +1,20 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleLikeRouter struct{}
+
+func (ArticleLikeRouter) InitArticleLikeRouter(Router *gin.RouterGroup) {
+	likeRouter := Router.Group("like")
+
+	articleLikeCtrl := controller.ApiGroupApp.SystemApiGroup.GetArticleLikeCtrl()
+	{
+		likeRouter.POST("toggle", articleLikeCtrl.ToggleLike) // 收藏/取消收藏
+		likeRouter.GET("status", articleLikeCtrl.LikeStatus)  // 是否已收藏
+		likeRouter.GET("list", articleLikeCtrl.LikedList)     // 我收藏的文章
+	}
+}
@@ -0,0 +1,20 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleRevisionRouter struct{}
+
+func (ArticleRevisionRouter) InitArticleRevisionRouter(Router *gin.RouterGroup) {
+	revisionRouter := Router.Group("article/revision")
+
+	articleRevisionCtrl := controller.ApiGroupApp.SystemApiGroup.GetArticleRevisionCtrl()
+	{
+		revisionRouter.GET("list", articleRevisionCtrl.RevisionList)        // 历史版本列表
+		revisionRouter.GET("diff", articleRevisionCtrl.RevisionDiff)        // 对比两个版本
+		revisionRouter.POST("restore", articleRevisionCtrl.RestoreRevision) // 恢复到指定版本
+	}
+}
@@ -13,10 +13,17 @@ func (ArticleRouter) InitArticleRouter(Router *gin.RouterGroup) {
 	articleRouter := Router.Group("article")
 
 	articleCtrl := controller.ApiGroupApp.SystemApiGroup.GetArticleCtrl()
+	articleExportCtrl := controller.ApiGroupApp.SystemApiGroup.GetArticleExportCtrl()
 	{
-		articleRouter.POST("create", articleCtrl.CreateArticle)   // 创建文章
-		articleRouter.DELETE("delete", articleCtrl.DeleteArticle) // 删除文章
-		articleRouter.PUT("update", articleCtrl.ArticleUpdate)    // 更新文章
-		articleRouter.GET("list", articleCtrl.ArticleList)        // 获取文章列表
+		articleRouter.POST("create", articleCtrl.CreateArticle)             // 创建文章
+		articleRouter.DELETE("delete", articleCtrl.DeleteArticle)           // 删除文章
+		articleRouter.PUT("update", articleCtrl.ArticleUpdate)              // 更新文章
+		articleRouter.GET("list", articleCtrl.ArticleList)                  // 获取文章列表
+		articleRouter.GET("search", articleCtrl.ArticleSearch)              // 关键字全文搜索
+		articleRouter.GET("suggest", articleCtrl.ArticleSuggest)            // 标题联想
+		articleRouter.GET("archive", articleCtrl.ArticleArchive)            // 文章归档
+		articleRouter.GET("archive/month", articleCtrl.ArticleArchiveMonth) // 按月查看归档
+		articleRouter.GET("export", articleExportCtrl.ArticleExport)        // 导出为 Markdown 压缩包
+		articleRouter.PUT("promote", articleCtrl.ArticlePromote)            // 设置置顶与精选（仅管理员）
 	}
 }
@@ -0,0 +1,23 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleSeriesRouter struct{}
+
+func (ArticleSeriesRouter) InitArticleSeriesRouter(Router *gin.RouterGroup) {
+	seriesRouter := Router.Group("article/series")
+
+	articleSeriesCtrl := controller.ApiGroupApp.SystemApiGroup.GetArticleSeriesCtrl()
+	{
+		seriesRouter.POST("create", articleSeriesCtrl.CreateSeries)       // 创建专栏
+		seriesRouter.PUT("update", articleSeriesCtrl.UpdateSeries)        // 更新专栏
+		seriesRouter.DELETE("delete", articleSeriesCtrl.DeleteSeries)     // 删除专栏
+		seriesRouter.PUT("articles", articleSeriesCtrl.SetSeriesArticles) // 设置专栏文章及顺序
+		seriesRouter.GET("list", articleSeriesCtrl.SeriesList)            // 专栏列表
+		seriesRouter.GET("detail", articleSeriesCtrl.SeriesDetail)        // 专栏详情
+	}
+}
@@ -0,0 +1,23 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArticleTaxonomyRouter struct{}
+
+// InitArticleTaxonomyRouter 分类与标签管理路由：列表登录可用，重命名/合并仅管理员
+func (ArticleTaxonomyRouter) InitArticleTaxonomyRouter(Router *gin.RouterGroup) {
+	categoryRouter := Router.Group("category")
+	tagRouter := Router.Group("tag")
+
+	articleTaxonomyCtrl := controller.ApiGroupApp.SystemApiGroup.GetArticleTaxonomyCtrl()
+	{
+		categoryRouter.GET("list", articleTaxonomyCtrl.CategoryList)     // 分类列表（含计数）
+		categoryRouter.PUT("rename", articleTaxonomyCtrl.CategoryRename) // 重命名分类
+		tagRouter.GET("list", articleTaxonomyCtrl.TagList)               // 标签列表（含计数）
+		tagRouter.PUT("merge", articleTaxonomyCtrl.TagMerge)             // 合并标签
+	}
+}
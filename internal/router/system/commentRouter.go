@@ -0,0 +1,20 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentRouter struct{}
+
+func (CommentRouter) InitCommentRouter(Router *gin.RouterGroup) {
+	commentRouter := Router.Group("comment")
+
+	commentCtrl := controller.ApiGroupApp.SystemApiGroup.GetCommentCtrl()
+	{
+		commentRouter.POST("create", commentCtrl.CreateComment)   // 发表评论/回复
+		commentRouter.GET("list", commentCtrl.CommentList)        // 获取文章评论（树形）
+		commentRouter.DELETE("delete", commentCtrl.DeleteComment) // 删除评论及其回复
+	}
+}
@@ -6,4 +6,13 @@ type RouterGroup struct {
 	UserRouter
 	ImageRouter
 	ArticleRouter
+	CommentRouter
+	ArticleLikeRouter
+	ArticleRevisionRouter
+	PublicRouter
+	ArticleTaxonomyRouter
+	EsOutboxRouter
+	FeedRouter
+	SeoRouter
+	ArticleSeriesRouter
 }
@@ -0,0 +1,20 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EsOutboxRouter struct{}
+
+// InitEsOutboxRouter ES 同步死信管理路由，仅管理员
+func (EsOutboxRouter) InitEsOutboxRouter(Router *gin.RouterGroup) {
+	outboxRouter := Router.Group("es/outbox")
+
+	esOutboxCtrl := controller.ApiGroupApp.SystemApiGroup.GetEsOutboxCtrl()
+	{
+		outboxRouter.GET("dead/list", esOutboxCtrl.DeadList)        // 死信列表
+		outboxRouter.POST("dead/requeue", esOutboxCtrl.DeadRequeue) // 死信重新入队
+	}
+}
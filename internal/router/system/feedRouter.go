@@ -0,0 +1,24 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedRouter struct{}
+
+// InitFeedRouter RSS/Atom 订阅源路由：匿名可访问，只包含公开且已发布的文章
+func (FeedRouter) InitFeedRouter(Router *gin.RouterGroup) {
+	feedRouter := Router.Group("feed")
+
+	feedCtrl := controller.ApiGroupApp.SystemApiGroup.GetFeedCtrl()
+	{
+		feedRouter.GET("rss.xml", feedCtrl.RSS)                      // 全站 RSS
+		feedRouter.GET("atom.xml", feedCtrl.Atom)                    // 全站 Atom
+		feedRouter.GET("category/:category/rss.xml", feedCtrl.RSS)   // 分类 RSS
+		feedRouter.GET("category/:category/atom.xml", feedCtrl.Atom) // 分类 Atom
+		feedRouter.GET("tag/:tag/rss.xml", feedCtrl.RSS)             // 标签 RSS
+		feedRouter.GET("tag/:tag/atom.xml", feedCtrl.Atom)           // 标签 Atom
+	}
+}
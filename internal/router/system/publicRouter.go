@@ -0,0 +1,30 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PublicRouter struct{}
+
+// InitPublicRouter 公开只读路由：匿名可访问，只返回公开且已发布的内容
+func (PublicRouter) InitPublicRouter(Router *gin.RouterGroup) {
+	publicRouter := Router.Group("public")
+
+	publicCtrl := controller.ApiGroupApp.SystemApiGroup.GetPublicCtrl()
+	{
+		publicRouter.GET("article/list", publicCtrl.ArticleList)                  // 文章列表
+		publicRouter.GET("article/detail", publicCtrl.ArticleDetail)              // 文章详情
+		publicRouter.GET("article/search", publicCtrl.ArticleSearch)              // 关键字全文搜索
+		publicRouter.GET("article/suggest", publicCtrl.ArticleSuggest)            // 标题联想
+		publicRouter.GET("article/related", publicCtrl.ArticleRelated)            // 相关文章推荐
+		publicRouter.GET("article/carousel", publicCtrl.ArticleCarousel)          // 首页轮播（精选文章）
+		publicRouter.GET("article/archive", publicCtrl.ArticleArchive)            // 文章归档
+		publicRouter.GET("article/archive/month", publicCtrl.ArticleArchiveMonth) // 按月查看归档
+		publicRouter.GET("category/list", publicCtrl.CategoryList)                // 分类列表
+		publicRouter.GET("tag/list", publicCtrl.TagList)                          // 标签列表
+		publicRouter.GET("series/list", publicCtrl.SeriesList)                    // 专栏列表
+		publicRouter.GET("series/detail", publicCtrl.SeriesDetail)                // 专栏详情
+	}
+}
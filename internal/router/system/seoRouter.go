@@ -0,0 +1,19 @@
+package system
+
+import (
+	"personal_blog/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeoRouter struct{}
+
+// InitSeoRouter 站点地图与 robots.txt 路由：匿名可访问
+func (SeoRouter) InitSeoRouter(Router *gin.RouterGroup) {
+	seoCtrl := controller.ApiGroupApp.SystemApiGroup.GetSeoCtrl()
+	{
+		Router.GET("sitemap.xml", seoCtrl.Sitemap)       // 站点地图（地址过多时为站点地图索引）
+		Router.GET("sitemap/:page", seoCtrl.SitemapPage) // 分页站点地图，如 /sitemap/1.xml
+		Router.GET("robots.txt", seoCtrl.Robots)         // robots.txt
+	}
+}
@@ -0,0 +1,358 @@
+package system
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"personal_blog/global"
+	"personal_blog/internal/model/consts"
+	resp "personal_blog/internal/model/dto/response"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"personal_blog/pkg/imageUtils"
+	"personal_blog/pkg/storage"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"go.uber.org/zap"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// exportDirTitleLength 导出目录名中标题部分的最大长度（字符数）
+	exportDirTitleLength = 60
+	// exportReportName 压缩包根目录下的导出报告，记录导出数量与未能打包的图片
+	exportReportName = "export-report.json"
+)
+
+// ArticleExportSvc 文章导出服务：将文章导出为 Markdown 压缩包，用于备份或迁移到其他平台
+// - 每篇文章一个目录：<日期>-<标题>/index.md，封面与插图下载到同目录的 images/ 下，正文中的链接改写为相对路径
+// - 根目录下的 export-report.json 记录导出的文章数、图片数与未能打包的图片
+// - 前言字段与 --md-import 一致（title、date、updated、categories、tags、cover、description、draft、visibility、publish_at），导出结果可直接重新导入
+type ArticleExportSvc struct {
+	imageRepo  interfaces.ImageRepository
+	articleSvc *ArticleSvc
+}
+
+// NewArticleExportSvc 创建文章导出服务实例
+func NewArticleExportSvc(group *repository.Group, articleSvc *ArticleSvc) *ArticleExportSvc {
+	return &ArticleExportSvc{
+		imageRepo:  group.SystemRepositorySupplier.GetImageRepository(),
+		articleSvc: articleSvc,
+	}
+}
+
+// Export 导出文章压缩包（不做权限校验，供命令行使用），id 为空时导出全部文章
+func (s *ArticleExportSvc) Export(
+	ctx context.Context,
+	w io.Writer,
+	id string,
+) (resp.ArticleExportResp, error) {
+	return s.export(ctx, w, id, nil)
+}
+
+// ExportFor 导出用户有权导出的文章
+// - 导出单篇时，与修改文章相同，仅作者或管理员可导出
+// - 导出全部时，管理员导出全部文章，其他用户只导出自己的文章
+func (s *ArticleExportSvc) ExportFor(
+	ctx context.Context,
+	operatorID uint,
+	id string,
+	w io.Writer,
+) (resp.ArticleExportResp, error) {
+	// 1、导出单篇：权限校验
+	if id != "" {
+		article, err := esUtil.Get(ctx, id)
+		if err != nil {
+			global.Log.Warn("获取文章失败", zap.String("id", id), zap.Error(err))
+			return resp.ArticleExportResp{}, fmt.Errorf("获取文章失败: %v", err)
+		}
+		if err = s.articleSvc.checkArticleOwner(ctx, operatorID, article); err != nil {
+			return resp.ArticleExportResp{}, err
+		}
+		return s.export(ctx, w, id, nil)
+	}
+	// 2、导出全部：非管理员只导出自己的文章
+	isAdmin, err := s.articleSvc.permissionService.IsAdmin(ctx, operatorID)
+	if err != nil {
+		global.Log.Error("获取用户角色失败", zap.Uint("userID", operatorID), zap.Error(err))
+		return resp.ArticleExportResp{}, fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	var query *types.Query
+	if !isAdmin {
+		query = &types.Query{Term: map[string]types.TermQuery{"author_id": {Value: operatorID}}}
+	}
+	return s.export(ctx, w, "", query)
+}
+
+// export 将文章逐篇写入压缩包：id 不为空时只导出该文章，否则按 query 导出（nil 为全部）
+func (s *ArticleExportSvc) export(
+	ctx context.Context,
+	w io.Writer,
+	id string,
+	query *types.Query,
+) (report resp.ArticleExportResp, err error) {
+	zw := zip.NewWriter(w)
+	dirs := make(map[string]struct{})
+	report.Missing = []string{}
+	// 1、逐篇写入
+	if id != "" {
+		article, gerr := esUtil.Get(ctx, id)
+		if gerr != nil {
+			global.Log.Warn("获取文章失败", zap.String("id", id), zap.Error(gerr))
+			return report, fmt.Errorf("获取文章失败: %v", gerr)
+		}
+		err = s.writeArticle(ctx, zw, exportDirName(id, article, dirs), article, &report)
+	} else {
+		// 渲染后的 HTML 与目录可由正文重新生成，不需要读取
+		source := types.SourceFilter{Excludes: []string{"content_html", "toc"}}
+		err = esUtil.ScrollHits(ctx, esModel.ArticleIndex(), query, source, func(hits []types.Hit) error {
+			for _, hit := range hits {
+				if hit.Id_ == nil {
+					continue
+				}
+				var article esModel.Article
+				if err := json.Unmarshal(hit.Source_, &article); err != nil {
+					return err
+				}
+				if err := s.writeArticle(ctx, zw, exportDirName(*hit.Id_, article, dirs), article, &report); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		global.Log.Error("导出文章失败", zap.Error(err))
+		return report, fmt.Errorf("导出文章失败: %v", err)
+	}
+	// 2、写入导出报告，通过接口下载时调用方只能从压缩包中得知哪些图片未能打包
+	if err = writeExportReport(zw, report); err != nil {
+		global.Log.Error("导出文章失败", zap.Error(err))
+		return report, fmt.Errorf("导出文章失败: %v", err)
+	}
+	// 3、写入压缩包目录
+	if err = zw.Close(); err != nil {
+		global.Log.Error("导出文章失败", zap.Error(err))
+		return report, fmt.Errorf("导出文章失败: %v", err)
+	}
+	return report, nil
+}
+
+// exportFrontMatter 导出文件的前言
+type exportFrontMatter struct {
+	Title       string   `yaml:"title"`
+	Date        string   `yaml:"date"`
+	Updated     string   `yaml:"updated,omitempty"`
+	Categories  []string `yaml:"categories,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Cover       string   `yaml:"cover,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Draft       bool     `yaml:"draft,omitempty"`
+	Visibility  string   `yaml:"visibility"`           // public/private
+	PublishAt   string   `yaml:"publish_at,omitempty"` // 定时发布的发布时间，仅定时发布的文章导出
+}
+
+// exportVisibility 前言中的可见范围取值
+func exportVisibility(article esModel.Article) string {
+	if article.IsPrivate() {
+		return "private"
+	}
+	return "public"
+}
+
+// writeArticle 将单篇文章及其图片写入压缩包的 dir 目录
+// - 只有图片库中有记录的图片才能通过存储驱动下载，外部图片或下载失败的图片保留原地址，记入 Missing
+func (s *ArticleExportSvc) writeArticle(
+	ctx context.Context,
+	zw *zip.Writer,
+	dir string,
+	article esModel.Article,
+	report *resp.ArticleExportResp,
+) error {
+	// 1、收集封面与插图
+	var urls []string
+	if article.Cover != "" {
+		urls = append(urls, article.Cover)
+	}
+	illustrations, err := imageUtils.FindIllustrations(article.Content)
+	if err != nil {
+		global.Log.Warn("解析插图失败", zap.String("title", article.Title), zap.Error(err))
+	}
+	urls = append(urls, illustrations...)
+	images, err := s.imageRepo.ListByURLs(ctx, urls)
+	if err != nil {
+		return fmt.Errorf("查询图片失败: %v", err)
+	}
+	byURL := make(map[string]*entity.Image, len(images))
+	for _, img := range images {
+		byURL[img.URL] = img
+	}
+	// 2、下载图片并写入 images/ 目录
+	links := make(map[string]string) // 原地址 -> 相对路径
+	names := make(map[string]struct{})
+	for _, url := range urls {
+		if _, ok := links[url]; ok {
+			continue
+		}
+		img, ok := byURL[url]
+		if !ok {
+			report.Missing = append(report.Missing, url)
+			continue
+		}
+		name := exportImageName(img, names)
+		if err = copyExportImage(ctx, zw, dir+"/images/"+name, img); err != nil {
+			global.Log.Warn("下载图片失败，保留原地址", zap.String("url", url), zap.Error(err))
+			report.Missing = append(report.Missing, url)
+			continue
+		}
+		links[url] = "images/" + name
+		report.Images++
+	}
+	// 3、生成 Markdown：前言 + 改写链接后的正文
+	front := exportFrontMatter{
+		Title:       article.Title,
+		Date:        article.CreatedAt,
+		Updated:     article.UpdatedAt,
+		Tags:        article.Tags,
+		Cover:       article.Cover,
+		Description: article.Abstract,
+		Draft:       article.Status == consts.ArticleDraft,
+		Visibility:  exportVisibility(article),
+	}
+	// 定时发布的文章保留创建时间，发布时间单独导出，重新导入后仍为定时发布
+	if article.Status == consts.ArticleScheduled {
+		front.PublishAt = article.PublishAt
+	} else if article.PublishAt != "" {
+		front.Date = article.PublishAt
+	}
+	if article.Category != "" {
+		front.Categories = []string{article.Category}
+	}
+	if link, ok := links[article.Cover]; ok {
+		front.Cover = link
+	}
+	meta, err := yaml.Marshal(front)
+	if err != nil {
+		return fmt.Errorf("生成前言失败: %v", err)
+	}
+	f, err := zw.Create(dir + "/index.md")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, "---\n"+string(meta)+"---\n\n"+rewriteImageLinks(article.Content, links)+"\n")
+	if err != nil {
+		return err
+	}
+	report.Articles++
+	return nil
+}
+
+// writeExportReport 将导出报告写入压缩包根目录
+func writeExportReport(zw *zip.Writer, report resp.ArticleExportResp) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := zw.Create(exportReportName)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// copyExportImage 通过图片所在的存储驱动下载图片，写入压缩包
+func copyExportImage(ctx context.Context, zw *zip.Writer, name string, img *entity.Image) error {
+	driver := storage.DriverFromName(img.Storage.String())
+	if driver == nil {
+		return fmt.Errorf("存储驱动 %s 未初始化", img.Storage.String())
+	}
+	rc, err := driver.Download(ctx, img.Key)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+// rewriteImageLinks 将正文中的图片地址改写为相对路径
+// - 按地址长度倒序替换，避免较短的地址先替换掉较长地址的前缀；地址后的片段（如 #pic_center）保留
+func rewriteImageLinks(content string, links map[string]string) string {
+	urls := make([]string, 0, len(links))
+	for url := range links {
+		urls = append(urls, url)
+	}
+	sort.Slice(urls, func(i, j int) bool { return len(urls[i]) > len(urls[j]) })
+	for _, url := range urls {
+		content = strings.ReplaceAll(content, url, links[url])
+	}
+	return content
+}
+
+// exportImageName 图片在压缩包中的文件名：取存储键的文件名部分，同一文章内重名时追加序号
+func exportImageName(img *entity.Image, used map[string]struct{}) string {
+	name := path.Base(strings.TrimSpace(img.Key))
+	if name == "." || name == "/" || name == "" {
+		name = strconv.FormatUint(uint64(img.ID), 10) + path.Ext(img.URL)
+	}
+	unique := name
+	for i := 1; ; i++ {
+		if _, ok := used[unique]; !ok {
+			break
+		}
+		unique = strconv.Itoa(i) + "-" + name
+	}
+	used[unique] = struct{}{}
+	return unique
+}
+
+// exportDirName 文章目录名：<日期>-<标题>，标题中不能出现在路径里的字符替换为“-”，重名时追加文章ID
+func exportDirName(id string, article esModel.Article, used map[string]struct{}) string {
+	// 1、日期取发布时间，没有时取创建时间
+	date := article.PublishAt
+	if date == "" {
+		date = article.CreatedAt
+	}
+	if len(date) >= 10 {
+		date = date[:10]
+	}
+	// 2、清理标题
+	var b strings.Builder
+	for _, r := range article.Title {
+		switch {
+		case unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r):
+			b.WriteByte('-')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	title := []rune(strings.Trim(b.String(), "-."))
+	if len(title) > exportDirTitleLength {
+		title = title[:exportDirTitleLength]
+	}
+	name := strings.Trim(date+"-"+string(title), "-")
+	if name == "" {
+		name = id
+	}
+	// 3、去重
+	if _, ok := used[name]; ok {
+		name += "-" + id
+	}
+	used[name] = struct{}{}
+	return name
+}
@@ -0,0 +1,163 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ArticleLikeSvc 文章收藏服务
+type ArticleLikeSvc struct {
+	articleLikeRepo interfaces.ArticleLikeRepository
+}
+
+// NewArticleLikeSvc 创建文章收藏服务实例
+func NewArticleLikeSvc(group *repository.Group) *ArticleLikeSvc {
+	return &ArticleLikeSvc{
+		articleLikeRepo: group.SystemRepositorySupplier.GetArticleLikeRepository(),
+	}
+}
+
+// ToggleLike 收藏/取消收藏文章
+// - 已收藏则取消，未收藏则收藏
+// - 依赖唯一索引保证并发重复请求不会重复计数
+func (a *ArticleLikeSvc) ToggleLike(
+	ctx context.Context,
+	userID uint,
+	req *request.ArticleLikeToggleReq,
+) (res resp.ArticleLikeResp, err error) {
+	// 1、校验文章是否存在且对当前用户可见
+	if err = checkArticleVisible(ctx, req.ArticleID, userID); err != nil {
+		return res, err
+	}
+	// 2、在事物中切换收藏状态，并同步文章收藏数
+	liked := false
+	err = a.articleLikeRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 2.a 先尝试取消收藏
+		deleted, err := a.articleLikeRepo.Delete(ctx, tx, req.ArticleID, userID)
+		if err != nil {
+			return fmt.Errorf("取消收藏失败: %v", err)
+		}
+		delta := -1
+		if !deleted {
+			// 2.b 未收藏过，则收藏；并发下重复插入会被唯一索引忽略
+			created, err := a.articleLikeRepo.Create(ctx, tx, req.ArticleID, userID)
+			if err != nil {
+				return fmt.Errorf("收藏失败: %v", err)
+			}
+			liked = true
+			if !created {
+				return nil
+			}
+			delta = 1
+		}
+		// 2.c 同步 ES 收藏数
+		if err = esUtil.IncrField(ctx, req.ArticleID, "likes", delta); err != nil {
+			global.Log.Error("更新文章收藏数失败",
+				zap.String("article_id", req.ArticleID), zap.Error(err))
+			return fmt.Errorf("更新文章收藏数失败: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	// 3、返回最新收藏数
+	return a.likeStatus(ctx, userID, req.ArticleID, liked)
+}
+
+// IsLiked 查询当前用户是否已收藏文章
+func (a *ArticleLikeSvc) IsLiked(
+	ctx context.Context,
+	userID uint,
+	req request.ArticleLikeStatusReq,
+) (res resp.ArticleLikeResp, err error) {
+	liked, err := a.articleLikeRepo.Exists(ctx, req.ArticleID, userID)
+	if err != nil {
+		return res, fmt.Errorf("查询收藏状态失败: %v", err)
+	}
+	return a.likeStatus(ctx, userID, req.ArticleID, liked)
+}
+
+// LikedArticles 我收藏的文章（按收藏时间倒序分页）
+// - 只返回对当前用户可见的文章
+func (a *ArticleLikeSvc) LikedArticles(
+	ctx context.Context,
+	userID uint,
+	info request.PageInfo,
+) (res resp.ArticleListResp, err error) {
+	// 1、分页参数
+	page := info.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := info.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	// 2、查询收藏的全部文章ID，过滤掉已删除、或收藏后被作者设为私密或撤回的文章，总数按可见文章计算
+	ids, err := a.articleLikeRepo.ListArticleIDsByUser(ctx, userID)
+	if err != nil {
+		return res, fmt.Errorf("获取收藏列表失败: %v", err)
+	}
+	matched, err := esUtil.MatchIDs(ctx, ids, esModel.VisibleQuery(userID))
+	if err != nil {
+		return res, fmt.Errorf("获取收藏文章失败: %v", err)
+	}
+	visible := make([]string, 0, len(matched))
+	for _, id := range ids {
+		if _, ok := matched[id]; ok {
+			visible = append(visible, id)
+		}
+	}
+	total := int64(len(visible))
+	// 3、分页后从 ES 批量获取当前页的文章
+	start := min((page-1)*pageSize, len(visible))
+	end := min(start+pageSize, len(visible))
+	hits, err := esUtil.GetByIDs(ctx, visible[start:end])
+	if err != nil {
+		return res, fmt.Errorf("获取收藏文章失败: %v", err)
+	}
+	items, err := resp.FromHits(hits, false)
+	if err != nil {
+		global.Log.Warn("结果映射失败", zap.Error(err))
+		return res, fmt.Errorf("结果映射失败: %v", err)
+	}
+	return resp.ArticleListResp{
+		List:       items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// likeStatus 组装收藏状态响应（收藏数取自 ES），文章对 userID 不可见时返回 ErrArticleNotFound
+func (a *ArticleLikeSvc) likeStatus(
+	ctx context.Context,
+	userID uint,
+	articleID string,
+	liked bool,
+) (resp.ArticleLikeResp, error) {
+	article, err := esUtil.Get(ctx, articleID)
+	if err != nil {
+		return resp.ArticleLikeResp{}, fmt.Errorf("获取文章失败: %v", err)
+	}
+	if !articleVisible(article, userID) {
+		return resp.ArticleLikeResp{}, ErrArticleNotFound
+	}
+	return resp.ArticleLikeResp{
+		ArticleID: articleID,
+		Liked:     liked,
+		Likes:     article.Likes,
+	}, nil
+}
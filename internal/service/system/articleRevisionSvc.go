@@ -0,0 +1,174 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"personal_blog/pkg/util"
+
+	"go.uber.org/zap"
+)
+
+// ArticleRevisionSvc 文章历史版本服务
+type ArticleRevisionSvc struct {
+	revisionRepo interfaces.ArticleRevisionRepository
+	articleSvc   *ArticleSvc
+}
+
+// NewArticleRevisionSvc 创建文章历史版本服务实例
+func NewArticleRevisionSvc(group *repository.Group, articleSvc *ArticleSvc) *ArticleRevisionSvc {
+	return &ArticleRevisionSvc{
+		revisionRepo: group.SystemRepositorySupplier.GetArticleRevisionRepository(),
+		articleSvc:   articleSvc,
+	}
+}
+
+// GetRevisionList 分页查询文章的历史版本（不含内容，按版本号倒序）
+// - 与修改文章相同，仅作者或管理员可查看
+func (a *ArticleRevisionSvc) GetRevisionList(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticleRevisionListReq,
+) (res resp.ArticleRevisionListResp, err error) {
+	// 1、权限校验
+	if err = a.checkRevisionAccess(ctx, operatorID, req.ArticleID); err != nil {
+		return res, err
+	}
+	// 1.a 分页参数
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	// 2、查询版本
+	revisions, total, err := a.revisionRepo.ListByArticleID(ctx, req.ArticleID, page, pageSize)
+	if err != nil {
+		global.Log.Error("查询文章历史版本失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+		return res, fmt.Errorf("查询文章历史版本失败: %v", err)
+	}
+	// 3、结果映射
+	list := make([]resp.ArticleRevisionItemResp, 0, len(revisions))
+	for _, r := range revisions {
+		list = append(list, resp.FromArticleRevision(r, false))
+	}
+	return resp.ArticleRevisionListResp{
+		List:       list,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// DiffRevisions 对比同一文章的两个历史版本
+func (a *ArticleRevisionSvc) DiffRevisions(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticleRevisionDiffReq,
+) (res resp.ArticleRevisionDiffResp, err error) {
+	// 1、获取两个版本
+	from, err := a.getRevision(ctx, req.From)
+	if err != nil {
+		return res, err
+	}
+	to, err := a.getRevision(ctx, req.To)
+	if err != nil {
+		return res, err
+	}
+	// 2、只能对比同一篇文章的版本
+	if from.ArticleID != to.ArticleID {
+		return res, fmt.Errorf("两个版本不属于同一篇文章")
+	}
+	// 2.a 权限校验
+	if err = a.checkRevisionAccess(ctx, operatorID, from.ArticleID); err != nil {
+		return res, err
+	}
+	// 3、逐字段对比
+	added, removed := util.DiffArrays(from.Tags, to.Tags)
+	return resp.ArticleRevisionDiffResp{
+		From:         resp.FromArticleRevision(from, false),
+		To:           resp.FromArticleRevision(to, false),
+		Title:        util.DiffLines(from.Title, to.Title),
+		Abstract:     util.DiffLines(from.Abstract, to.Abstract),
+		Content:      util.DiffLines(from.Content, to.Content),
+		AddedTags:    added,
+		RemovedTags:  removed,
+		CategoryFrom: from.Category,
+		CategoryTo:   to.Category,
+	}, nil
+}
+
+// RestoreRevision 将文章恢复到指定历史版本
+// - 通过 UpdateArticle 完成，分类/标签计数与图片类别同步调整，恢复本身也会记录为一个新版本
+func (a *ArticleRevisionSvc) RestoreRevision(
+	ctx context.Context,
+	editorID uint,
+	req request.ArticleRevisionRestoreReq,
+) (*entity.ArticleRevision, error) {
+	// 1、获取版本
+	revision, err := a.getRevision(ctx, req.RevisionID)
+	if err != nil {
+		return nil, err
+	}
+	// 2、获取当前文章，版本中未记录的字段（可见范围、状态）保持不变
+	current, err := esUtil.Get(ctx, revision.ArticleID)
+	if err != nil {
+		global.Log.Warn("获取文章失败", zap.String("id", revision.ArticleID), zap.Error(err))
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+	cover := revision.Cover
+	if cover == "" {
+		cover = current.Cover
+	}
+	// 3、按版本内容更新文章
+	updateReq := request.ArticleUpdateReq{
+		ID:           revision.ArticleID,
+		Cover:        cover,
+		Title:        revision.Title,
+		Category:     revision.Category,
+		Tags:         revision.Tags,
+		Abstract:     revision.Abstract,
+		Content:      revision.Content,
+		VisibleRange: current.Visibility(),
+	}
+	if err = a.articleSvc.UpdateArticle(ctx, editorID, updateReq); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// checkRevisionAccess 校验用户是否可以查看文章的历史版本：作者本人或管理员
+func (a *ArticleRevisionSvc) checkRevisionAccess(
+	ctx context.Context,
+	operatorID uint,
+	articleID string,
+) error {
+	article, err := esUtil.Get(ctx, articleID)
+	if err != nil {
+		global.Log.Warn("获取文章失败", zap.String("id", articleID), zap.Error(err))
+		return fmt.Errorf("获取文章失败: %v", err)
+	}
+	return a.articleSvc.checkArticleOwner(ctx, operatorID, article)
+}
+
+// getRevision 获取历史版本，不存在时返回错误
+func (a *ArticleRevisionSvc) getRevision(ctx context.Context, id uint) (*entity.ArticleRevision, error) {
+	revision, err := a.revisionRepo.GetByID(ctx, id)
+	if err != nil {
+		global.Log.Error("获取文章历史版本失败", zap.Uint("id", id), zap.Error(err))
+		return nil, fmt.Errorf("获取文章历史版本失败: %v", err)
+	}
+	if revision == nil {
+		return nil, fmt.Errorf("历史版本不存在: %d", id)
+	}
+	return revision, nil
+}
@@ -0,0 +1,287 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSeriesNotFound 专栏不存在
+	ErrSeriesNotFound = errors.New("专栏不存在")
+	// ErrSeriesForbidden 无权操作他人专栏
+	ErrSeriesForbidden = errors.New("无权限操作其他用户的专栏")
+	// ErrSeriesArticleInvalid 专栏文章列表不合法（文章不存在、重复或已属于其他专栏）
+	ErrSeriesArticleInvalid = errors.New("专栏文章不合法")
+)
+
+// ArticleSeriesSvc 文章专栏服务：专栏由多篇文章按顺序组成，用于发布分多篇的教程等
+// - 一篇文章最多属于一个专栏，文章详情中返回所属专栏及前后篇导航
+// - 仅专栏创建者或管理员可修改专栏；加入专栏的文章须是操作者有权修改的文章
+type ArticleSeriesSvc struct {
+	seriesRepo interfaces.ArticleSeriesRepository
+	articleSvc *ArticleSvc
+}
+
+// NewArticleSeriesSvc 创建文章专栏服务实例
+func NewArticleSeriesSvc(group *repository.Group, articleSvc *ArticleSvc) *ArticleSeriesSvc {
+	return &ArticleSeriesSvc{
+		seriesRepo: group.SystemRepositorySupplier.GetArticleSeriesRepository(),
+		articleSvc: articleSvc,
+	}
+}
+
+// CreateSeries 创建专栏
+func (s *ArticleSeriesSvc) CreateSeries(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticleSeriesCreateReq,
+) (resp.ArticleSeriesItemResp, error) {
+	series := &entity.ArticleSeries{
+		Title:       req.Title,
+		Description: req.Description,
+		Cover:       req.Cover,
+		AuthorID:    operatorID,
+	}
+	err := s.seriesRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 1、创建专栏
+		if err := s.seriesRepo.Create(ctx, tx, series); err != nil {
+			global.Log.Error("创建专栏失败", zap.String("title", req.Title), zap.Error(err))
+			return fmt.Errorf("创建专栏失败: %v", err)
+		}
+		// 2、修改封面类别
+		return updateCoverCategory(ctx, tx, "", series.Cover)
+	})
+	if err != nil {
+		return resp.ArticleSeriesItemResp{}, err
+	}
+	return resp.FromArticleSeries(series), nil
+}
+
+// UpdateSeries 更新专栏的标题、简介与封面
+func (s *ArticleSeriesSvc) UpdateSeries(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticleSeriesUpdateReq,
+) error {
+	// 1、获取专栏并校验权限
+	series, err := s.getOwnedSeries(ctx, operatorID, req.ID)
+	if err != nil {
+		return err
+	}
+	oldCover := series.Cover
+	series.Title, series.Description, series.Cover = req.Title, req.Description, req.Cover
+	return s.seriesRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 2、更新专栏
+		if err := s.seriesRepo.Update(ctx, tx, series); err != nil {
+			global.Log.Error("更新专栏失败", zap.Uint("id", req.ID), zap.Error(err))
+			return fmt.Errorf("更新专栏失败: %v", err)
+		}
+		// 3、修改封面类别
+		return updateCoverCategory(ctx, tx, oldCover, series.Cover)
+	})
+}
+
+// DeleteSeries 删除专栏，专栏中的文章保留，仅解除与专栏的关联
+func (s *ArticleSeriesSvc) DeleteSeries(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticleSeriesDeleteReq,
+) error {
+	// 1、获取专栏并校验权限
+	series, err := s.getOwnedSeries(ctx, operatorID, req.ID)
+	if err != nil {
+		return err
+	}
+	return s.seriesRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 2、删除专栏及文章顺序
+		if err := s.seriesRepo.Delete(ctx, tx, series.ID); err != nil {
+			global.Log.Error("删除专栏失败", zap.Uint("id", req.ID), zap.Error(err))
+			return fmt.Errorf("删除专栏失败: %v", err)
+		}
+		// 3、初始化封面类别
+		return updateCoverCategory(ctx, tx, series.Cover, "")
+	})
+}
+
+// SetSeriesArticles 设置专栏中的文章及其顺序
+// - 以请求中的顺序整体替换，可同时完成加入、移出与调整顺序
+// - 文章须存在、不重复、不属于其他专栏，且操作者有权修改该文章
+func (s *ArticleSeriesSvc) SetSeriesArticles(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticleSeriesArticlesReq,
+) error {
+	// 1、获取专栏并校验权限
+	series, err := s.getOwnedSeries(ctx, operatorID, req.ID)
+	if err != nil {
+		return err
+	}
+	// 2、校验文章：不重复、存在、有权修改
+	seen := make(map[string]struct{}, len(req.ArticleIDs))
+	for _, id := range req.ArticleIDs {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: 文章 %s 重复", ErrSeriesArticleInvalid, id)
+		}
+		seen[id] = struct{}{}
+	}
+	hits, err := esUtil.GetByIDs(ctx, req.ArticleIDs)
+	if err != nil {
+		global.Log.Warn("获取文章失败", zap.Strings("ids", req.ArticleIDs), zap.Error(err))
+		return fmt.Errorf("获取文章失败: %v", err)
+	}
+	if len(hits) != len(req.ArticleIDs) {
+		found := make(map[string]struct{}, len(hits))
+		for _, hit := range hits {
+			found[*hit.Id_] = struct{}{}
+		}
+		for _, id := range req.ArticleIDs {
+			if _, ok := found[id]; !ok {
+				return fmt.Errorf("%w: 文章 %s 不存在", ErrSeriesArticleInvalid, id)
+			}
+		}
+	}
+	for _, hit := range hits {
+		var article esModel.Article
+		if err = json.Unmarshal(hit.Source_, &article); err != nil {
+			return fmt.Errorf("解析文章失败: %v", err)
+		}
+		if err = s.articleSvc.checkArticleOwner(ctx, operatorID, article); err != nil {
+			return err
+		}
+	}
+	return s.seriesRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 3、文章不能属于其他专栏
+		items, err := s.seriesRepo.ListItemsByArticleIDs(ctx, tx, req.ArticleIDs)
+		if err != nil {
+			global.Log.Error("查询文章所属专栏失败", zap.Strings("ids", req.ArticleIDs), zap.Error(err))
+			return fmt.Errorf("查询文章所属专栏失败: %v", err)
+		}
+		for _, item := range items {
+			if item.SeriesID != series.ID {
+				return fmt.Errorf("%w: 文章 %s 已属于其他专栏", ErrSeriesArticleInvalid, item.ArticleID)
+			}
+		}
+		// 4、按新顺序写入
+		if err = s.seriesRepo.ReplaceArticles(ctx, tx, series.ID, req.ArticleIDs); err != nil {
+			global.Log.Error("设置专栏文章失败", zap.Uint("id", series.ID), zap.Error(err))
+			return fmt.Errorf("设置专栏文章失败: %v", err)
+		}
+		return nil
+	})
+}
+
+// SeriesList 分页查询专栏
+func (s *ArticleSeriesSvc) SeriesList(
+	ctx context.Context,
+	req request.ArticleSeriesListReq,
+) (res resp.ArticleSeriesListResp, err error) {
+	// 1、分页参数
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	// 2、查询专栏
+	list, total, err := s.seriesRepo.List(ctx, page, pageSize)
+	if err != nil {
+		global.Log.Error("查询专栏列表失败", zap.Error(err))
+		return res, fmt.Errorf("查询专栏列表失败: %v", err)
+	}
+	// 3、结果映射
+	items := make([]resp.ArticleSeriesItemResp, 0, len(list))
+	for _, series := range list {
+		items = append(items, resp.FromArticleSeries(series))
+	}
+	return resp.ArticleSeriesListResp{
+		List:       items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// SeriesDetail 专栏详情：专栏信息与按顺序排列的文章
+// - 只返回对 viewerID 可见的文章；viewerID 为 0 表示游客
+func (s *ArticleSeriesSvc) SeriesDetail(
+	ctx context.Context,
+	viewerID uint,
+	req request.ArticleSeriesDetailReq,
+) (resp.ArticleSeriesDetailResp, error) {
+	// 1、获取专栏
+	series, err := s.getSeries(ctx, req.ID)
+	if err != nil {
+		return resp.ArticleSeriesDetailResp{}, err
+	}
+	// 2、获取文章
+	articles, err := s.articleSvc.seriesArticles(ctx, series.ID, viewerID)
+	if err != nil {
+		global.Log.Error("获取专栏文章失败", zap.Uint("id", series.ID), zap.Error(err))
+		return resp.ArticleSeriesDetailResp{}, err
+	}
+	return resp.ArticleSeriesDetailResp{
+		ArticleSeriesItemResp: resp.FromArticleSeries(series),
+		Articles:              articles,
+	}, nil
+}
+
+// PublicSeriesDetail 公开专栏详情（匿名访问）
+func (s *ArticleSeriesSvc) PublicSeriesDetail(
+	ctx context.Context,
+	req request.ArticleSeriesDetailReq,
+) (resp.ArticleSeriesDetailResp, error) {
+	return s.SeriesDetail(ctx, guestViewerID, req)
+}
+
+// getSeries 获取专栏，不存在时返回 ErrSeriesNotFound
+func (s *ArticleSeriesSvc) getSeries(ctx context.Context, id uint) (*entity.ArticleSeries, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		global.Log.Error("查询专栏失败", zap.Uint("id", id), zap.Error(err))
+		return nil, fmt.Errorf("查询专栏失败: %v", err)
+	}
+	if series == nil {
+		return nil, ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+// getOwnedSeries 获取专栏并校验操作者为专栏创建者或管理员
+func (s *ArticleSeriesSvc) getOwnedSeries(
+	ctx context.Context,
+	operatorID uint,
+	id uint,
+) (*entity.ArticleSeries, error) {
+	series, err := s.getSeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if operatorID != 0 && series.AuthorID == operatorID {
+		return series, nil
+	}
+	isAdmin, err := s.articleSvc.permissionService.IsAdmin(ctx, operatorID)
+	if err != nil {
+		global.Log.Error("获取用户角色失败", zap.Uint("userID", operatorID), zap.Error(err))
+		return nil, fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	if !isAdmin {
+		return nil, ErrSeriesForbidden
+	}
+	return series, nil
+}
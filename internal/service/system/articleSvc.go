@@ -3,23 +3,33 @@ package system
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/calendarinterval"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/fieldtype"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/highlighterencoder"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/scriptsorttype"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/textquerytype"
+	"math"
 	"personal_blog/global"
 	"personal_blog/internal/model/consts"
 	"personal_blog/internal/model/dto/request"
 	resp "personal_blog/internal/model/dto/response"
 	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/internal/model/entity"
 	"personal_blog/internal/repository"
 	"personal_blog/internal/repository/interfaces"
 	"personal_blog/pkg/articleUtils"
 	esUtil "personal_blog/pkg/elasticSearch"
 	"personal_blog/pkg/imageUtils"
+	"personal_blog/pkg/markdown"
 	"personal_blog/pkg/util"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"strings"
@@ -27,20 +37,85 @@ import (
 
 // ArticleSvc 文章服务
 type ArticleSvc struct {
-	articleRepo interfaces.ArticleRepository
+	articleRepo  interfaces.ArticleRepository
+	commentRepo  interfaces.CommentRepository
+	likeRepo     interfaces.ArticleLikeRepository
+	revisionRepo interfaces.ArticleRevisionRepository
+	seriesRepo   interfaces.ArticleSeriesRepository
+
+	permissionService *PermissionService
+	esOutboxSvc       *EsOutboxSvc
 }
 
 // NewArticleSvc 创建文章服务实例
-func NewArticleSvc(group *repository.Group) *ArticleSvc {
+// - 文章的 ES 写入通过发件箱与 MySQL 变更在同一事务中登记
+func NewArticleSvc(
+	group *repository.Group,
+	permissionService *PermissionService,
+	esOutboxSvc *EsOutboxSvc,
+) *ArticleSvc {
 	return &ArticleSvc{
-		articleRepo: group.SystemRepositorySupplier.GetArticleRepository(),
+		articleRepo:       group.SystemRepositorySupplier.GetArticleRepository(),
+		commentRepo:       group.SystemRepositorySupplier.GetCommentRepository(),
+		likeRepo:          group.SystemRepositorySupplier.GetArticleLikeRepository(),
+		revisionRepo:      group.SystemRepositorySupplier.GetArticleRevisionRepository(),
+		seriesRepo:        group.SystemRepositorySupplier.GetArticleSeriesRepository(),
+		permissionService: permissionService,
+		esOutboxSvc:       esOutboxSvc,
 	}
 }
 
+var (
+	// ErrArticleForbidden 无权操作他人文章
+	ErrArticleForbidden = errors.New("无权限操作其他用户的文章")
+	// ErrArticleNotFound 文章不存在或对当前用户不可见
+	ErrArticleNotFound = errors.New("文章不存在")
+	// ErrPromotionInvalid 置顶/精选的截止时间不合法
+	ErrPromotionInvalid = errors.New("截止时间不合法")
+	// ErrCursorInvalid 游标无效或已过期，需从第一页重新加载
+	ErrCursorInvalid = esUtil.ErrCursorInvalid
+)
+
+// ArticleCreate 创建文章，并记录作者
 func (a *ArticleSvc) ArticleCreate(
 	ctx context.Context,
+	authorID uint,
+	authorUUID uuid.UUID,
 	req *request.ArticleCreateReq,
 ) error {
+	_, err := a.createArticle(ctx, authorID, authorUUID, req, time.Time{}, time.Time{})
+	return err
+}
+
+// ArticleImport 导入文章（如从 Hexo/Hugo 迁移），返回新文章ID
+// - 与 ArticleCreate 走同一流程，分类/标签计数与图片类别同样会更新
+// - 保留原文的创建与更新时间，updatedAt 为零值时与 createdAt 相同
+func (a *ArticleSvc) ArticleImport(
+	ctx context.Context,
+	authorID uint,
+	authorUUID uuid.UUID,
+	req *request.ArticleCreateReq,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (string, error) {
+	if createdAt.IsZero() {
+		return "", fmt.Errorf("导入文章必须指定创建时间")
+	}
+	if updatedAt.IsZero() || updatedAt.Before(createdAt) {
+		updatedAt = createdAt
+	}
+	return a.createArticle(ctx, authorID, authorUUID, req, createdAt, updatedAt)
+}
+
+// createArticle 创建文章，createdAt/updatedAt 为零值时取当前时间
+func (a *ArticleSvc) createArticle(
+	ctx context.Context,
+	authorID uint,
+	authorUUID uuid.UUID,
+	req *request.ArticleCreateReq,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (string, error) {
 	// 1、通过关键字判断文章是存在
 	b, err := articleUtils.Exists(ctx, req.Title)
 	if err != nil {
@@ -50,10 +125,25 @@ func (a *ArticleSvc) ArticleCreate(
 		global.Log.Warn("文章标题重复，继续创建", zap.String("title", req.Title))
 	}
 	// 2、设置结构体
-	now := time.Now().Format("2006-01-02 15:04:05")
+	// 2.a 解析文章状态，不传时默认直接发布
+	status, publishAt, err := resolveArticleStatus(req.Status, req.PublishAt)
+	if err != nil {
+		return "", err
+	}
+	if status == consts.ArticleArchived {
+		return "", fmt.Errorf("新建文章不能直接归档")
+	}
+	// 2.b 构建文章
+	now := time.Now()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	if updatedAt.IsZero() {
+		updatedAt = now
+	}
 	articleToCreate := &esModel.Article{
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		CreatedAt:    createdAt.Local().Format("2006-01-02 15:04:05"),
+		UpdatedAt:    updatedAt.Local().Format("2006-01-02 15:04:05"),
 		Cover:        req.Cover,
 		Title:        req.Title,
 		Keyword:      req.Title,
@@ -62,9 +152,23 @@ func (a *ArticleSvc) ArticleCreate(
 		Abstract:     req.Abstract,
 		Content:      req.Content,
 		VisibleRange: req.VisibleRange,
+		AuthorID:     authorID,
+		AuthorUUID:   authorUUID.String(),
+		Status:       status,
+	}
+	if publishAt != nil {
+		articleToCreate.PublishAt = *publishAt
+	}
+	// 2.c 渲染正文，HTML 与目录随文章一起写入 ES
+	rendered, err := renderContent(articleToCreate.Content)
+	if err != nil {
+		return "", err
 	}
+	articleToCreate.ContentHTML, articleToCreate.TOC = rendered.HTML, rendered.TOC
+	// 2.d 预先生成文档ID，使发件箱中的写入操作可以安全重试
+	articleID := uuid.Must(uuid.NewV4()).String()
 	// 3、在事物中创建文章，并更新相关消息
-	return a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+	err = a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
 		// 3.a 更新文章中的分类：新建文章仅需对新分类+1或创建
 		if articleToCreate.Category != "" {
 			if err = a.articleRepo.IncOrCreateCategory(ctx, tx, articleToCreate.Category); err != nil {
@@ -79,28 +183,46 @@ func (a *ArticleSvc) ArticleCreate(
 				zap.Strings("tags", articleToCreate.Tags), zap.Error(err))
 			return fmt.Errorf("更新标签计数失败: %v", err)
 		}
-		// 3.c 创建文章
-		err = articleUtils.CreateArticle(ctx, articleToCreate)
-		if err != nil {
-			global.Log.Error("创建文章失败",
-				zap.String("title", articleToCreate.Title), zap.Error(err))
-			return fmt.Errorf("创建文章失败: %v", err)
+		// 3.c 修改封面与插图类别
+		if err = updateCoverCategory(ctx, tx, "", articleToCreate.Cover); err != nil {
+			return err
+		}
+		if err = updateIllustrationsCategory(ctx, tx, "", articleToCreate.Content); err != nil {
+			return err
+		}
+		// 3.d 登记创建文章，事务提交后同步到 ES
+		if err = a.esOutboxSvc.Enqueue(ctx, tx, articleID, consts.EsOutboxIndex, articleToCreate); err != nil {
+			return err
 		}
 		return nil
 	})
+	if err != nil {
+		return "", err
+	}
+	// 4、立即同步到 ES，失败时由定时任务重试
+	a.dispatchArticle(ctx, articleID)
+	return articleID, nil
 }
 
 // ArticleDelete 删除文章
+// - 仅文章作者或管理员可删除
 func (a *ArticleSvc) ArticleDelete(
 	ctx context.Context,
+	operatorID uint,
 	req *request.ArticleDeleteReq,
 ) error {
 	// 1、无数据，直接返回
 	if len(req.IDs) == 0 {
 		return nil
 	}
+	// 1.a 文章还有未同步的操作时，ES 中的数据可能是旧的，不能据此调整计数
+	for _, id := range req.IDs {
+		if err := a.esOutboxSvc.DispatchArticle(ctx, id); err != nil {
+			return err
+		}
+	}
 	// 2、开启事物
-	return a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+	err := a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
 		// 3、逐个删除
 		for _, id := range req.IDs {
 			// 3.a 获取文章
@@ -110,6 +232,10 @@ func (a *ArticleSvc) ArticleDelete(
 					zap.String("id", id), zap.Error(err))
 				return fmt.Errorf("获取文章失败: %v", err)
 			}
+			// 3.a.1 权限校验
+			if err = a.checkArticleOwner(ctx, operatorID, articleToDelete); err != nil {
+				return err
+			}
 			// 3.b 删除文章类别
 			if err = a.articleRepo.DecOrDeleteCategory(ctx, tx, articleToDelete.Category); err != nil {
 				global.Log.Error("更新分类计数失败",
@@ -143,23 +269,53 @@ func (a *ArticleSvc) ArticleDelete(
 					zap.Strings("urls", imageSlice), zap.Error(err))
 				return fmt.Errorf("修改插图类别失败: %v", err)
 			}
-			// 3.d
-			// 同时删除所有评论 todo
-			// 3.e 删除文章
-			var ids = []string{id}
-			if err = esUtil.Delete(ctx, ids); err != nil {
-				global.Log.Error("删除文章失败",
+			// 3.e 同时删除所有评论
+			if err = a.commentRepo.DeleteByArticleID(ctx, tx, id); err != nil {
+				global.Log.Error("删除文章评论失败",
+					zap.String("id", id), zap.Error(err))
+				return fmt.Errorf("删除文章评论失败: %v", err)
+			}
+			// 3.f 同时删除所有收藏
+			if err = a.likeRepo.DeleteByArticleID(ctx, tx, id); err != nil {
+				global.Log.Error("删除文章收藏失败",
 					zap.String("id", id), zap.Error(err))
-				return fmt.Errorf("删除文章失败: %v", err)
+				return fmt.Errorf("删除文章收藏失败: %v", err)
+			}
+			// 3.g 同时删除所有历史版本
+			if err = a.revisionRepo.DeleteByArticleID(ctx, tx, id); err != nil {
+				global.Log.Error("删除文章历史版本失败",
+					zap.String("id", id), zap.Error(err))
+				return fmt.Errorf("删除文章历史版本失败: %v", err)
+			}
+			// 3.h 同时移出所属专栏
+			if err = a.seriesRepo.DeleteItemByArticleID(ctx, tx, id); err != nil {
+				global.Log.Error("移出文章所属专栏失败",
+					zap.String("id", id), zap.Error(err))
+				return fmt.Errorf("移出文章所属专栏失败: %v", err)
+			}
+			// 3.i 登记删除文章，事务提交后同步到 ES
+			if err = a.esOutboxSvc.Enqueue(ctx, tx, id, consts.EsOutboxDelete, nil); err != nil {
+				return err
 			}
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	// 4、立即同步到 ES，失败时由定时任务重试
+	for _, id := range req.IDs {
+		a.dispatchArticle(ctx, id)
+	}
+	return nil
 }
 
 // UpdateArticle 更新文章
+// - 仅文章作者或管理员可更新
+// - 每次更新都会保存一份历史版本快照，editorID 为本次编辑者
 func (a *ArticleSvc) UpdateArticle(
 	ctx context.Context,
+	editorID uint,
 	req request.ArticleUpdateReq,
 ) error {
 	now := time.Now().Format("2006-01-02 15:04:05")
@@ -173,6 +329,15 @@ func (a *ArticleSvc) UpdateArticle(
 		Abstract     string   `json:"abstract"`
 		VisibleRange uint     `json:"visible_range"`
 		Content      string   `json:"content"`
+
+		// 清空早期文档的 visibleRange，避免旧值继续影响可见性判断
+		LegacyVisibleRange *uint `json:"visibleRange"`
+
+		ContentHTML string             `json:"content_html"`
+		TOC         []markdown.Heading `json:"toc"`
+
+		Status    consts.ArticleStatus `json:"status"`
+		PublishAt *string              `json:"publish_at"` // 为 nil 时清空发布时间
 	}{
 		UpdatedAt:    now,
 		Cover:        req.Cover,
@@ -184,13 +349,47 @@ func (a *ArticleSvc) UpdateArticle(
 		VisibleRange: req.VisibleRange,
 		Content:      req.Content,
 	}
-	return a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+	// 渲染正文，HTML 与目录随文章一起写入 ES
+	rendered, err := renderContent(articleToUpdate.Content)
+	if err != nil {
+		return err
+	}
+	articleToUpdate.ContentHTML, articleToUpdate.TOC = rendered.HTML, rendered.TOC
+	// 文章还有未同步的操作时，ES 中的旧文章可能不是最新的，不能据此调整计数
+	if err = a.esOutboxSvc.DispatchArticle(ctx, req.ID); err != nil {
+		return err
+	}
+	err = a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
 		// 1、获取旧文章
 		oldArticle, err := esUtil.Get(ctx, req.ID)
 		if err != nil {
 			global.Log.Warn("获取旧文章失败", zap.String("id", req.ID), zap.Error(err))
 			return fmt.Errorf("获取旧文章失败: %v", err)
 		}
+		// 1.a 权限校验
+		if err = a.checkArticleOwner(ctx, editorID, oldArticle); err != nil {
+			return err
+		}
+		// 1.b 解析文章状态：未传状态时保持原状态与发布时间
+		articleToUpdate.Status, articleToUpdate.PublishAt = oldArticle.Status, nil
+		if oldArticle.PublishAt != "" {
+			articleToUpdate.PublishAt = &oldArticle.PublishAt
+		}
+		if req.Status != consts.ArticleStatusNone {
+			status, publishAt, err := resolveArticleStatus(req.Status, req.PublishAt)
+			if err != nil {
+				return err
+			}
+			// 1.c 已发布的文章再次发布时保留首次发布时间
+			if status == consts.ArticlePublished && oldArticle.Status.IsPublished() && req.PublishAt == "" && oldArticle.PublishAt != "" {
+				publishAt = &oldArticle.PublishAt
+			}
+			// 1.d 归档不改变发布时间
+			if status == consts.ArticleArchived {
+				publishAt = articleToUpdate.PublishAt
+			}
+			articleToUpdate.Status, articleToUpdate.PublishAt = status, publishAt
+		}
 		// 2、更新分类计数
 		if err = a.updateCategoryCounts(ctx, tx, oldArticle.Category, articleToUpdate.Category); err != nil {
 			return err
@@ -207,27 +406,357 @@ func (a *ArticleSvc) UpdateArticle(
 		if err = updateIllustrationsCategory(ctx, tx, oldArticle.Content, articleToUpdate.Content); err != nil {
 			return err
 		}
-		// 6、更新文章
-		if err = esUtil.Update(ctx, req.ID, articleToUpdate); err != nil {
-			global.Log.Error("更新文章内容失败", zap.String("id", req.ID), zap.Error(err))
-			return fmt.Errorf("更新文章内容失败: %v", err)
+		// 6、保存历史版本
+		// 6.a 尚无历史版本的文章（如早于版本功能创建），先把更新前的内容存为基线版本
+		count, err := a.revisionRepo.CountByArticleID(ctx, tx, req.ID)
+		if err != nil {
+			global.Log.Error("查询文章历史版本失败", zap.String("id", req.ID), zap.Error(err))
+			return fmt.Errorf("查询文章历史版本失败: %v", err)
+		}
+		if count == 0 {
+			baseline := &entity.ArticleRevision{
+				ArticleID: req.ID,
+				Cover:     oldArticle.Cover,
+				Title:     oldArticle.Title,
+				Category:  oldArticle.Category,
+				Tags:      oldArticle.Tags,
+				Abstract:  oldArticle.Abstract,
+				Content:   oldArticle.Content,
+				EditorID:  oldArticle.AuthorID,
+			}
+			if err = a.revisionRepo.Create(ctx, tx, baseline); err != nil {
+				global.Log.Error("保存文章基线版本失败", zap.String("id", req.ID), zap.Error(err))
+				return fmt.Errorf("保存文章基线版本失败: %v", err)
+			}
+		}
+		// 6.b 保存本次更新后的内容
+		revision := &entity.ArticleRevision{
+			ArticleID: req.ID,
+			Cover:     articleToUpdate.Cover,
+			Title:     articleToUpdate.Title,
+			Category:  articleToUpdate.Category,
+			Tags:      articleToUpdate.Tags,
+			Abstract:  articleToUpdate.Abstract,
+			Content:   articleToUpdate.Content,
+			EditorID:  editorID,
+		}
+		if err = a.revisionRepo.Create(ctx, tx, revision); err != nil {
+			global.Log.Error("保存文章历史版本失败", zap.String("id", req.ID), zap.Error(err))
+			return fmt.Errorf("保存文章历史版本失败: %v", err)
+		}
+		// 7、登记更新文章，事务提交后同步到 ES
+		if err = a.esOutboxSvc.Enqueue(ctx, tx, req.ID, consts.EsOutboxUpdate, articleToUpdate); err != nil {
+			return err
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	// 8、立即同步到 ES，失败时由定时任务重试
+	a.dispatchArticle(ctx, req.ID)
+	return nil
+}
+
+// dispatchArticle 事务提交后立即把文章的待同步操作写入 ES
+// - 此时 MySQL 已提交，同步失败只记录日志，由发件箱定时任务重试
+func (a *ArticleSvc) dispatchArticle(ctx context.Context, articleID string) {
+	if err := a.esOutboxSvc.DispatchArticle(ctx, articleID); err != nil {
+		global.Log.Warn("文章暂未同步到ES，将由定时任务重试", zap.String("id", articleID), zap.Error(err))
+	}
 }
 
 // GetArticleList 文章列表
+// - 读者只能看到已发布且非“仅我可见”的文章，作者还能看到自己的草稿与私密文章
 func (a *ArticleSvc) GetArticleList(
 	ctx context.Context,
+	viewerID uint,
 	info request.ArticleListReq,
 ) (res resp.ArticleListResp, err error) {
 	// 1、ID查询
 	if info.ID != nil && *info.ID != "" {
 		// 1.a 按ID查询，查询到结构后，直接退出
-		return a.articleListByID(ctx, *info.ID)
+		return a.articleListByID(ctx, *info.ID, viewerID)
+	}
+	// 2、构建查询请求
+	req := buildArticleSearchRequest(info, viewerID)
+	option := esModel.EsOption{
+		PageInfo:       info.PageInfo,
+		Index:          esModel.ArticleIndex(),
+		Request:        req,
+		IncludeContent: false,
+	}
+	// 3、分页查询（传入游标时按游标翻页）并返回结果
+	return articlePage(ctx, option, info.Cursor)
+}
+
+// SearchArticles 关键字全文搜索
+// - 在标题、摘要、正文中检索，标题权重最高，结果带高亮片段
+// - 可见性规则与文章列表一致
+func (a *ArticleSvc) SearchArticles(
+	ctx context.Context,
+	viewerID uint,
+	info request.ArticleSearchReq,
+) (res resp.ArticleListResp, err error) {
+	// 1、构建查询请求
+	option := esModel.EsOption{
+		PageInfo:       info.PageInfo,
+		Index:          esModel.ArticleIndex(),
+		Request:        buildKeywordSearchRequest(info, viewerID),
+		IncludeContent: false,
+	}
+	// 2、分页查询（传入游标时按游标翻页，按相关性排序），结果含高亮片段
+	res, err = articlePage(ctx, option, info.Cursor)
+	if err != nil && !errors.Is(err, ErrCursorInvalid) {
+		global.Log.Error("搜索文章失败", zap.String("keyword", info.Keyword), zap.Error(err))
+		return res, fmt.Errorf("搜索文章失败: %v", err)
+	}
+	return res, err
+}
+
+// PublicSearchArticles 公开关键字搜索（匿名访问）
+func (a *ArticleSvc) PublicSearchArticles(
+	ctx context.Context,
+	info request.ArticleSearchReq,
+) (resp.ArticleListResp, error) {
+	return a.SearchArticles(ctx, guestViewerID, info)
+}
+
+const (
+	// defaultSuggestSize 标题联想默认返回条数
+	defaultSuggestSize = 5
+	// maxSuggestSize 标题联想最多返回条数
+	maxSuggestSize = 20
+)
+
+// SuggestTitles 搜索框标题联想：按已输入内容前缀匹配标题，返回前 N 条文章的ID与标题
+// - 可见性规则与文章列表一致
+func (a *ArticleSvc) SuggestTitles(
+	ctx context.Context,
+	viewerID uint,
+	info request.ArticleSuggestReq,
+) ([]resp.ArticleSuggestResp, error) {
+	// 1、返回条数
+	size := info.Size
+	if size < 1 {
+		size = defaultSuggestSize
+	}
+	if size > maxSuggestSize {
+		size = maxSuggestSize
 	}
+	// 2、search_as_you_type 推荐用法：对子字段及其 shingle 子字段做 bool_prefix 匹配
+	field := "title." + esModel.TitleSuggestField
+	query := &types.Query{Bool: &types.BoolQuery{
+		Must: []types.Query{{MultiMatch: &types.MultiMatchQuery{
+			Query:  strings.TrimSpace(info.Keyword),
+			Type:   &textquerytype.Boolprefix,
+			Fields: []string{field, field + "._2gram", field + "._3gram"},
+		}}},
+		Filter: []types.Query{esModel.VisibleQuery(viewerID)},
+	}}
+	option := esModel.EsOption{
+		Index:          esModel.ArticleIndex(),
+		Request:        &search.Request{Query: query},
+		SourceIncludes: []string{"title"},
+	}
+	option.Page = 1
+	option.PageSize = size
+	// 3、查询
+	hits, _, err := esUtil.EsPagination(ctx, option)
+	if err != nil {
+		global.Log.Error("标题联想失败", zap.String("keyword", info.Keyword), zap.Error(err))
+		return nil, fmt.Errorf("标题联想失败: %v", err)
+	}
+	// 4、结果映射
+	list := make([]resp.ArticleSuggestResp, 0, len(hits))
+	for _, hit := range hits {
+		var source struct {
+			Title string `json:"title"`
+		}
+		if err = json.Unmarshal(hit.Source_, &source); err != nil {
+			global.Log.Warn("结果映射失败", zap.Error(err))
+			return nil, fmt.Errorf("结果映射失败: %v", err)
+		}
+		item := resp.ArticleSuggestResp{Title: source.Title}
+		if hit.Id_ != nil {
+			item.ID = *hit.Id_
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// PublicSuggestTitles 公开标题联想（匿名访问）
+func (a *ArticleSvc) PublicSuggestTitles(
+	ctx context.Context,
+	info request.ArticleSuggestReq,
+) ([]resp.ArticleSuggestResp, error) {
+	return a.SuggestTitles(ctx, guestViewerID, info)
+}
+
+const (
+	// defaultRelatedSize 相关文章默认返回条数
+	defaultRelatedSize = 5
+	// maxRelatedSize 相关文章最多返回条数
+	maxRelatedSize = 20
+)
+
+// RelatedArticles 相关文章推荐：按标题、摘要、正文的相似度（more_like_this）查找其他公开文章
+// - 与当前文章有相同标签、分类的文章额外加分，只有标签或分类相同的文章也会入选
+// - 当前文章本身不在结果中；当前文章对游客不可见时按不存在处理
+func (a *ArticleSvc) RelatedArticles(
+	ctx context.Context,
+	info request.ArticleRelatedReq,
+) ([]resp.ArticleItemResp, error) {
+	// 1、返回条数
+	size := info.Size
+	if size < 1 {
+		size = defaultRelatedSize
+	}
+	if size > maxRelatedSize {
+		size = maxRelatedSize
+	}
+	// 2、获取当前文章（同时校验对游客可见）
+	source, err := a.PublicArticleDetail(ctx, info.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 3、构建查询请求
+	option := esModel.EsOption{
+		Index:          esModel.ArticleIndex(),
+		Request:        &search.Request{Query: buildRelatedQuery(source)},
+		IncludeContent: false,
+	}
+	option.Page = 1
+	option.PageSize = size
+	// 4、查询
+	hits, _, err := esUtil.EsPagination(ctx, option)
+	if err != nil {
+		global.Log.Error("查询相关文章失败", zap.String("id", info.ID), zap.Error(err))
+		return nil, fmt.Errorf("查询相关文章失败: %v", err)
+	}
+	// 5、结果映射
+	items, err := resp.FromHits(hits, false)
+	if err != nil {
+		global.Log.Warn("结果映射失败", zap.Error(err))
+		return nil, fmt.Errorf("结果映射失败: %v", err)
+	}
+	return items, nil
+}
+
+// buildRelatedQuery 构建相关文章查询
+func buildRelatedQuery(source resp.ArticleItemResp) *types.Query {
+	index := esModel.ArticleIndex()
+	id := source.ID
+	// 1、正文相似度：以当前文章为样本，词频、文档频率下限放宽到 1，适配文章数量较少的博客
+	minFreq, maxTerms := 1, 25
+	should := []types.Query{{MoreLikeThis: &types.MoreLikeThisQuery{
+		Fields:        []string{"title", "abstract", "content"},
+		Like:          []types.Like{types.LikeDocument{Index_: &index, Id_: &id}},
+		MinTermFreq:   &minFreq,
+		MinDocFreq:    &minFreq,
+		MaxQueryTerms: &maxTerms,
+	}}}
+	// 2、相同标签、分类加分
+	if len(source.Tags) > 0 {
+		tags := make([]types.FieldValue, 0, len(source.Tags))
+		for _, tag := range source.Tags {
+			tags = append(tags, tag)
+		}
+		tagBoost := float32(2)
+		should = append(should, types.Query{Terms: &types.TermsQuery{
+			TermsQuery: map[string]types.TermsQueryField{"tags": tags},
+			Boost:      &tagBoost,
+		}})
+	}
+	if source.Category != "" {
+		should = append(should, types.Query{Term: map[string]types.TermQuery{"category": {Value: source.Category}}})
+	}
+	// 3、至少命中一项，只推荐游客可见的文章，并排除当前文章
+	return &types.Query{Bool: &types.BoolQuery{
+		Should:             should,
+		MinimumShouldMatch: 1,
+		Filter:             []types.Query{esModel.VisibleQuery(guestViewerID)},
+		MustNot:            []types.Query{{Ids: &types.IdsQuery{Values: []string{id}}}},
+	}}
+}
+
+// archiveArticlesPerMonth 归档中每月附带的文章数，其余文章通过按月查询分页获取
+const archiveArticlesPerMonth = 10
+
+// Archive 文章归档：按创建时间统计 年 -> 月 -> 文章数，每月附带最新的若干篇文章（按时间倒序）
+// - 可见性规则与文章列表一致
+func (a *ArticleSvc) Archive(
+	ctx context.Context,
+	viewerID uint,
+) ([]resp.ArchiveYearResp, error) {
+	// 1、按月做日期直方图聚合
+	query := esModel.VisibleQuery(viewerID)
+	buckets, err := esUtil.DateHistogram(ctx, &query, "created_at", calendarinterval.Month, "yyyy-MM",
+		archiveArticlesPerMonth, []string{"title", "created_at"})
+	if err != nil {
+		global.Log.Error("统计文章归档失败", zap.Error(err))
+		return nil, fmt.Errorf("统计文章归档失败: %v", err)
+	}
+	// 2、按年归组（分桶已按月份倒序）
+	years := make([]resp.ArchiveYearResp, 0)
+	for _, b := range buckets {
+		month, err := time.Parse("2006-01", b.Key)
+		if err != nil {
+			global.Log.Warn("解析归档月份失败", zap.String("key", b.Key), zap.Error(err))
+			continue
+		}
+		// 2.a 当月文章
+		articles := make([]resp.ArchiveArticleResp, 0, len(b.Hits))
+		for _, hit := range b.Hits {
+			var source struct {
+				Title     string `json:"title"`
+				CreatedAt string `json:"created_at"`
+			}
+			if err = json.Unmarshal(hit.Source_, &source); err != nil {
+				global.Log.Warn("结果映射失败", zap.Error(err))
+				return nil, fmt.Errorf("结果映射失败: %v", err)
+			}
+			item := resp.ArchiveArticleResp{Title: source.Title, CreatedAt: source.CreatedAt}
+			if hit.Id_ != nil {
+				item.ID = *hit.Id_
+			}
+			articles = append(articles, item)
+		}
+		// 2.b 归入年份
+		if len(years) == 0 || years[len(years)-1].Year != month.Year() {
+			years = append(years, resp.ArchiveYearResp{Year: month.Year(), Months: []resp.ArchiveMonthResp{}})
+		}
+		year := &years[len(years)-1]
+		year.Count += b.Count
+		year.Months = append(year.Months, resp.ArchiveMonthResp{
+			Month:    int(month.Month()),
+			Count:    b.Count,
+			Articles: articles,
+		})
+	}
+	return years, nil
+}
+
+// ArchiveMonth 按月查看归档：分页返回指定月份创建的文章（按时间倒序）
+// - 可见性规则与文章列表一致
+func (a *ArticleSvc) ArchiveMonth(
+	ctx context.Context,
+	viewerID uint,
+	info request.ArticleArchiveMonthReq,
+) (res resp.ArticleListResp, err error) {
+	// 1、当月时间范围 [月初, 下月初)
+	start := time.Date(info.Year, time.Month(info.Month), 1, 0, 0, 0, 0, time.Local)
+	from := start.Format("2006-01-02 15:04:05")
+	to := start.AddDate(0, 1, 0).Format("2006-01-02 15:04:05")
 	// 2、构建查询请求
-	req := buildArticleSearchRequest(info)
+	req := &search.Request{
+		Query: &types.Query{Bool: &types.BoolQuery{Filter: []types.Query{
+			esModel.VisibleQuery(viewerID),
+			{Range: map[string]types.RangeQuery{"created_at": types.DateRangeQuery{Gte: &from, Lt: &to}}},
+		}}},
+		Sort: []types.SortCombinations{types.SortOptions{SortOptions: map[string]types.FieldSort{"created_at": {Order: &sortorder.Desc}}}},
+	}
 	option := esModel.EsOption{
 		PageInfo:       info.PageInfo,
 		Index:          esModel.ArticleIndex(),
@@ -237,7 +766,8 @@ func (a *ArticleSvc) GetArticleList(
 	// 3、分页查询
 	hits, total, err := esUtil.EsPagination(ctx, option)
 	if err != nil {
-		return res, err
+		global.Log.Error("查询月度归档失败", zap.Int("year", info.Year), zap.Int("month", info.Month), zap.Error(err))
+		return res, fmt.Errorf("查询月度归档失败: %v", err)
 	}
 	// 4、结果映射
 	items, err := resp.FromHits(hits, false)
@@ -246,34 +776,171 @@ func (a *ArticleSvc) GetArticleList(
 		return res, fmt.Errorf("结果映射失败: %v", err)
 	}
 	// 5、返回结果（包含分页元数据）
-	// 5.a 第几页
+	return articleListResp(items, total, info.PageInfo), nil
+}
+
+// PublicArchive 公开文章归档（匿名访问）
+func (a *ArticleSvc) PublicArchive(ctx context.Context) ([]resp.ArchiveYearResp, error) {
+	return a.Archive(ctx, guestViewerID)
+}
+
+// PublicArchiveMonth 公开按月查看归档（匿名访问）
+func (a *ArticleSvc) PublicArchiveMonth(
+	ctx context.Context,
+	info request.ArticleArchiveMonthReq,
+) (resp.ArticleListResp, error) {
+	return a.ArchiveMonth(ctx, guestViewerID, info)
+}
+
+// articlePage 执行文章列表查询并组装响应
+// - cursor 为 nil 时按页码分页；否则使用游标分页，响应中只返回下一页游标，不返回页码与总页数
+func articlePage(
+	ctx context.Context,
+	option esModel.EsOption,
+	cursor *string,
+) (res resp.ArticleListResp, err error) {
+	// 1、查询
+	var (
+		hits  []types.Hit
+		total int64
+		next  string
+	)
+	if cursor != nil {
+		hits, total, next, err = esUtil.EsCursorPagination(ctx, option, *cursor)
+	} else {
+		hits, total, err = esUtil.EsPagination(ctx, option)
+	}
+	if err != nil {
+		return res, err
+	}
+	// 2、结果映射
+	items, err := resp.FromHits(hits, false)
+	if err != nil {
+		global.Log.Warn("结果映射失败", zap.Error(err))
+		return res, fmt.Errorf("结果映射失败: %v", err)
+	}
+	// 3、按页码分页时返回分页元数据
+	if cursor == nil {
+		return articleListResp(items, total, option.PageInfo), nil
+	}
+	pageSize := option.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	return resp.ArticleListResp{
+		List:       items,
+		Total:      total,
+		PageSize:   pageSize,
+		NextCursor: next,
+	}, nil
+}
+
+// articleListResp 组装分页列表响应
+func articleListResp(
+	items []resp.ArticleItemResp,
+	total int64,
+	info request.PageInfo,
+) resp.ArticleListResp {
+	// 1、第几页
 	page := info.Page
 	if page < 1 {
 		page = 1
 	}
-	// 5.b 页大小
+	// 2、页大小
 	pageSize := info.PageSize
 	if pageSize < 1 {
 		pageSize = 10
 	}
-	// 6、计算总页数
-	totalPages := 0
-	totalPages = int((total + int64(pageSize) - 1) / int64(pageSize))
-
-	res = resp.ArticleListResp{
+	// 3、计算总页数
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	return resp.ArticleListResp{
 		List:       items,
 		Total:      total,
 		Page:       page,
 		PageSize:   pageSize,
 		TotalPages: totalPages,
 	}
-	return res, nil
+}
+
+// guestViewerID 匿名访问者按游客（consts.Guest）处理：没有用户ID，只能看到公开且已发布的文章
+const guestViewerID uint = 0
+
+// maxTaxonomyBuckets 分类/标签聚合返回的最大数量
+const maxTaxonomyBuckets = 1000
+
+// PublicArticleList 公开文章列表（匿名访问）
+func (a *ArticleSvc) PublicArticleList(
+	ctx context.Context,
+	info request.ArticlePublicListReq,
+) (resp.ArticleListResp, error) {
+	return a.GetArticleList(ctx, guestViewerID, request.ArticleListReq{
+		Title:      info.Title,
+		Category:   info.Category,
+		Tag:        info.Tag,
+		Abstract:   info.Abstract,
+		PageInfo:   info.PageInfo,
+		CursorInfo: info.CursorInfo,
+	})
+}
+
+// PublicArticleDetail 公开文章详情（含正文，匿名访问）
+func (a *ArticleSvc) PublicArticleDetail(
+	ctx context.Context,
+	id string,
+) (resp.ArticleItemResp, error) {
+	res, err := a.articleListByID(ctx, id, guestViewerID)
+	if err != nil {
+		return resp.ArticleItemResp{}, err
+	}
+	if len(res.List) == 0 {
+		return resp.ArticleItemResp{}, ErrArticleNotFound
+	}
+	return res.List[0], nil
+}
+
+// PublicCategoryList 公开分类列表：只统计公开且已发布的文章
+func (a *ArticleSvc) PublicCategoryList(
+	ctx context.Context,
+	req request.ArticleTaxonomyListReq,
+) ([]resp.CategoryCountResp, error) {
+	query := esModel.VisibleQuery(guestViewerID)
+	buckets, err := esUtil.TermsCount(ctx, &query, "category", maxTaxonomyBuckets)
+	if err != nil {
+		global.Log.Error("统计分类失败", zap.Error(err))
+		return nil, fmt.Errorf("统计分类失败: %v", err)
+	}
+	buckets = arrangeTermBuckets(buckets, req)
+	list := make([]resp.CategoryCountResp, 0, len(buckets))
+	for _, b := range buckets {
+		list = append(list, resp.CategoryCountResp{Category: b.Key, Number: b.Count})
+	}
+	return list, nil
+}
+
+// PublicTagList 公开标签列表：只统计公开且已发布的文章
+func (a *ArticleSvc) PublicTagList(
+	ctx context.Context,
+	req request.ArticleTaxonomyListReq,
+) ([]resp.TagCountResp, error) {
+	query := esModel.VisibleQuery(guestViewerID)
+	buckets, err := esUtil.TermsCount(ctx, &query, "tags", maxTaxonomyBuckets)
+	if err != nil {
+		global.Log.Error("统计标签失败", zap.Error(err))
+		return nil, fmt.Errorf("统计标签失败: %v", err)
+	}
+	buckets = arrangeTermBuckets(buckets, req)
+	list := make([]resp.TagCountResp, 0, len(buckets))
+	for _, b := range buckets {
+		list = append(list, resp.TagCountResp{Tag: b.Key, Number: b.Count})
+	}
+	return list, nil
 }
 
 // articleListByID 按ID查询文章
 func (a *ArticleSvc) articleListByID(
 	ctx context.Context,
 	id string,
+	viewerID uint,
 ) (res resp.ArticleListResp, err error) {
 	// 1、按ID查询
 	esRes, gerr := global.ESClient.Get(esModel.ArticleIndex(), id).Do(ctx)
@@ -293,16 +960,130 @@ func (a *ArticleSvc) articleListByID(
 			zap.String("id", id), zap.Error(uerr))
 		return res, fmt.Errorf("解析文章失败: %v", uerr)
 	}
+	// 3.a 未发布或“仅我可见”的文章仅作者本人可见，对其他人按不存在处理
+	if !articleVisible(art, viewerID) {
+		return resp.ArticleListResp{List: []resp.ArticleItemResp{}, Total: 0}, nil
+	}
+	// 3.b 早于 Markdown 渲染功能写入的文章没有保存 HTML，读取时即时渲染
+	if art.ContentHTML == "" && art.Content != "" {
+		rendered, rerr := renderContent(art.Content)
+		if rerr != nil {
+			return res, rerr
+		}
+		art.ContentHTML, art.TOC = rendered.HTML, rendered.TOC
+	}
 	// 4、结构转换
 	item := resp.FromArticle(id, art, true)
+	// 4.a 所属专栏导航，失败不影响文章本身的返回
+	if item.Series, err = a.articleSeriesNav(ctx, id, viewerID); err != nil {
+		global.Log.Warn("获取专栏导航失败", zap.String("id", id), zap.Error(err))
+	}
 	// 5、返回结果（按ID查询：单页单条）
 	return resp.ArticleListResp{
 		List: []resp.ArticleItemResp{item}, Total: 1, Page: 1, PageSize: 1, TotalPages: 1,
 	}, nil
 }
 
+// articleVisible 文章对指定用户是否可见，与 esModel.VisibleQuery 的条件一致
+// - 未发布或“仅我可见”的文章仅作者本人可见；viewerID 为 0 表示游客
+func articleVisible(art esModel.Article, viewerID uint) bool {
+	if viewerID != 0 && art.AuthorID == viewerID {
+		return true
+	}
+	return art.Status.IsPublished() && !art.IsPrivate()
+}
+
+// checkArticleVisible 校验文章存在且对 viewerID 可见，否则返回 ErrArticleNotFound
+func checkArticleVisible(ctx context.Context, articleID string, viewerID uint) error {
+	article, err := esUtil.Get(ctx, articleID)
+	if err != nil {
+		global.Log.Warn("获取文章失败", zap.String("id", articleID), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrArticleNotFound, err)
+	}
+	if !articleVisible(article, viewerID) {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+// articleSeriesNav 文章所属专栏及专栏内的前后篇导航，文章不属于任何专栏时返回 nil
+// - 只在当前用户可见的文章间导航，跳过草稿、“仅我可见”等文章
+func (a *ArticleSvc) articleSeriesNav(
+	ctx context.Context,
+	articleID string,
+	viewerID uint,
+) (*resp.ArticleSeriesNavResp, error) {
+	// 1、查询所属专栏
+	item, err := a.seriesRepo.GetItemByArticleID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("查询文章所属专栏失败: %v", err)
+	}
+	if item == nil {
+		return nil, nil
+	}
+	series, err := a.seriesRepo.GetByID(ctx, item.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("查询专栏失败: %v", err)
+	}
+	if series == nil {
+		return nil, nil
+	}
+	// 2、专栏中当前用户可见的文章
+	chapters, err := a.seriesArticles(ctx, series.ID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	// 3、定位当前文章
+	nav := &resp.ArticleSeriesNavResp{ID: series.ID, Title: series.Title, Total: len(chapters)}
+	for i, c := range chapters {
+		if c.ID != articleID {
+			continue
+		}
+		nav.Index = i + 1
+		if i > 0 {
+			nav.Prev = &resp.ArticleSeriesChapterResp{ID: chapters[i-1].ID, Title: chapters[i-1].Title}
+		}
+		if i+1 < len(chapters) {
+			nav.Next = &resp.ArticleSeriesChapterResp{ID: chapters[i+1].ID, Title: chapters[i+1].Title}
+		}
+		break
+	}
+	return nav, nil
+}
+
+// seriesArticles 按专栏顺序返回其中当前用户可见的文章（不含正文）
+func (a *ArticleSvc) seriesArticles(
+	ctx context.Context,
+	seriesID uint,
+	viewerID uint,
+) ([]resp.ArticleItemResp, error) {
+	// 1、按顺序获取文章ID
+	ids, err := a.seriesRepo.ListArticleIDs(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("查询专栏文章失败: %v", err)
+	}
+	// 2、批量获取文章（结果顺序与 ids 一致，已删除的文章会被跳过）
+	hits, err := esUtil.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("获取专栏文章失败: %v", err)
+	}
+	// 3、过滤不可见的文章
+	list := make([]resp.ArticleItemResp, 0, len(hits))
+	for _, hit := range hits {
+		var art esModel.Article
+		if err = json.Unmarshal(hit.Source_, &art); err != nil {
+			return nil, fmt.Errorf("解析文章失败: %v", err)
+		}
+		if !articleVisible(art, viewerID) {
+			continue
+		}
+		list = append(list, resp.FromArticle(*hit.Id_, art, false))
+	}
+	return list, nil
+}
+
 // buildArticleSearchRequest 构建搜索请求
-func buildArticleSearchRequest(info request.ArticleListReq) *search.Request {
+func buildArticleSearchRequest(info request.ArticleListReq, viewerID uint) *search.Request {
 	// 1、创建搜索请求
 	req := &search.Request{Query: &types.Query{}}
 	// 2、设置查询条件(and查询)
@@ -328,20 +1109,115 @@ func buildArticleSearchRequest(info request.ArticleListReq) *search.Request {
 		boolQuery.Filter = append(boolQuery.Filter, types.Query{Term: map[string]types.TermQuery{"tags": {Value: info.Tag}}})
 	}
 	// 7、设置可见范围
-	if info.VisibleRange != 0 {
-		boolQuery.Filter = append(boolQuery.Filter, types.Query{Term: map[string]types.TermQuery{"visible_range": {Value: info.VisibleRange}}})
+	// 7.a 早期文档的可见范围写在 visibleRange 中，统一通过 PrivateQuery 判断
+	switch info.VisibleRange {
+	case esModel.VisiblePrivate:
+		boolQuery.Filter = append(boolQuery.Filter, esModel.PrivateQuery())
+	case esModel.VisiblePublic:
+		boolQuery.MustNot = append(boolQuery.MustNot, esModel.PrivateQuery())
+	}
+	// 8、设置可见性：读者只看已发布的公开文章，作者还可看到自己的全部文章
+	boolQuery.Filter = append(boolQuery.Filter, esModel.VisibleQuery(viewerID))
+	// 8.a 按状态筛选（未发布状态只会命中作者自己的文章）
+	if info.Status != nil {
+		if info.Status.IsPublished() {
+			boolQuery.Filter = append(boolQuery.Filter, esModel.PublishedQuery())
+		} else {
+			boolQuery.Filter = append(boolQuery.Filter, types.Query{Term: map[string]types.TermQuery{"status": {Value: *info.Status}}})
+		}
 	}
-	// 8、根据需求，获取指定查询内容
-	// 或者获取所有查询内容(带有时间排序)
+	// 9、根据需求，获取指定查询内容
+	// 或者获取所有查询内容
 	if boolQuery.Must != nil || boolQuery.Filter != nil {
 		req.Query.Bool = boolQuery
 	} else {
 		req.Query.MatchAll = &types.MatchAllQuery{}
-		req.Sort = []types.SortCombinations{types.SortOptions{SortOptions: map[string]types.FieldSort{"created_at": {Order: &sortorder.Desc}}}}
+	}
+	// 10、置顶文章排在最前；其余按相关性排序，没有全文匹配条件时不存在相关性排序，按时间倒序
+	req.Sort = pinnedSort()
+	if boolQuery.Must == nil {
+		req.Sort = append(req.Sort, types.SortOptions{SortOptions: map[string]types.FieldSort{"created_at": {Order: &sortorder.Desc}}})
+	} else {
+		req.Sort = append(req.Sort, types.SortOptions{Score_: &types.ScoreSort{Order: &sortorder.Desc}})
 	}
 	return req
 }
 
+// pinnedSortScript 置顶排序值：生效中的置顶文章取置顶权重（缺失为 0），未置顶或置顶已到期的文章取 params.unpinned
+// - 早于置顶功能创建的索引可能还没有这些字段，containsKey 为 false 时按未置顶处理
+const pinnedSortScript = `
+if (!doc.containsKey('pinned') || doc['pinned'].size() == 0 || !doc['pinned'].value) {
+	return params.unpinned;
+}
+if (doc.containsKey('pin_until') && doc['pin_until'].size() > 0
+		&& doc['pin_until'].value.toInstant().toEpochMilli() <= params.now) {
+	return params.unpinned;
+}
+return doc.containsKey('pin_weight') && doc['pin_weight'].size() > 0 ? doc['pin_weight'].value : 0;
+`
+
+// pinnedUnpinnedValue 未置顶文章的排序值，小于任何置顶权重（int32），未置顶文章之间按后续排序条件排序
+const pinnedUnpinnedValue int64 = math.MinInt32 - 1
+
+// pinnedSort 置顶文章优先的排序条件：生效中的置顶文章在前，置顶文章之间按置顶权重倒序
+// - 置顶到期后由定时任务取消，在此之前按 pin_until 判断，到期的置顶不再排在最前
+func pinnedSort() []types.SortCombinations {
+	// pin_until 按不带时区的 "2006-01-02 15:04:05" 存储，ES 按 UTC 解析；当前时间按同样方式换算，与 ExpirePromotions 的比较保持一致
+	now, _ := time.ParseInLocation("2006-01-02 15:04:05", time.Now().Format("2006-01-02 15:04:05"), time.UTC)
+	nowRaw, _ := json.Marshal(now.UnixMilli())
+	unpinnedRaw, _ := json.Marshal(pinnedUnpinnedValue)
+	source := pinnedSortScript
+	return []types.SortCombinations{
+		types.SortOptions{Script_: &types.ScriptSort{
+			Type:  &scriptsorttype.Number,
+			Order: &sortorder.Desc,
+			Script: types.Script{
+				Source: &source,
+				Params: map[string]json.RawMessage{"now": nowRaw, "unpinned": unpinnedRaw},
+			},
+		}},
+	}
+}
+
+// buildKeywordSearchRequest 构建关键字全文搜索请求
+func buildKeywordSearchRequest(info request.ArticleSearchReq, viewerID uint) *search.Request {
+	keyword := strings.TrimSpace(info.Keyword)
+	boolQuery := &types.BoolQuery{}
+	// 1、标题、摘要、正文联合检索，按字段设置权重
+	boolQuery.Must = []types.Query{{MultiMatch: &types.MultiMatchQuery{
+		Query:  keyword,
+		Fields: []string{"title^3", "abstract^2", "content"},
+	}}}
+	// 2、整句命中正文的文章额外加分，便于用正文中的一句话找到原文
+	boolQuery.Should = []types.Query{{MatchPhrase: map[string]types.MatchPhraseQuery{
+		"content": {Query: keyword},
+	}}}
+	// 3、设置类别、标签
+	if info.Category != nil && strings.TrimSpace(*info.Category) != "" {
+		boolQuery.Filter = append(boolQuery.Filter, types.Query{Term: map[string]types.TermQuery{"category": {Value: *info.Category}}})
+	}
+	if info.Tag != nil && strings.TrimSpace(*info.Tag) != "" {
+		boolQuery.Filter = append(boolQuery.Filter, types.Query{Term: map[string]types.TermQuery{"tags": {Value: *info.Tag}}})
+	}
+	// 4、设置可见性
+	boolQuery.Filter = append(boolQuery.Filter, esModel.VisibleQuery(viewerID))
+	// 5、高亮：标题与摘要整段返回，正文返回命中片段；编码为 html 以免正文中的标签被直接渲染
+	fragmentSize, fragments, whole := 120, 3, 0
+	return &search.Request{
+		Query: &types.Query{Bool: boolQuery},
+		Highlight: &types.Highlight{
+			Encoder:  &highlighterencoder.Html,
+			PreTags:  []string{"<em>"},
+			PostTags: []string{"</em>"},
+			Fields: map[string]types.HighlightField{
+				"title":    {NumberOfFragments: &whole},
+				"abstract": {NumberOfFragments: &whole},
+				"content":  {FragmentSize: &fragmentSize, NumberOfFragments: &fragments},
+			},
+		},
+	}
+}
+
 /*
 
 // must 会对搜索词分词处理 会进行评分，"golang"可能匹配到"go"、"golang教程"等
@@ -382,6 +1258,261 @@ func buildArticleSearchRequest(info request.ArticleListReq) *search.Request {
     }
 */
 
+// PublishScheduled 发布所有到期的定时文章，返回发布的文章数
+func (a *ArticleSvc) PublishScheduled(ctx context.Context) (int64, error) {
+	// 1、查询条件：定时发布且发布时间已到
+	now := time.Now().Format("2006-01-02 15:04:05")
+	query := &types.Query{Bool: &types.BoolQuery{Filter: []types.Query{
+		{Term: map[string]types.TermQuery{"status": {Value: consts.ArticleScheduled}}},
+		{Range: map[string]types.RangeQuery{"publish_at": types.DateRangeQuery{Lte: &now}}},
+	}}}
+	// 2、更新脚本：将状态改为已发布
+	statusRaw, err := json.Marshal(consts.ArticlePublished)
+	if err != nil {
+		return 0, err
+	}
+	source := "ctx._source.status = params.status"
+	script := &types.Script{
+		Source: &source,
+		Params: map[string]json.RawMessage{"status": statusRaw},
+	}
+	// 3、批量更新，版本冲突跳过的文章仍满足查询条件，由下一轮定时任务继续发布
+	res, err := esUtil.UpdateByQuery(ctx, query, script)
+	if err != nil {
+		global.Log.Error("发布定时文章失败", zap.Error(err))
+		return 0, fmt.Errorf("发布定时文章失败: %v", err)
+	}
+	if res.Updated > 0 {
+		invalidatePublicCache()
+	}
+	return res.Updated, nil
+}
+
+// PromoteArticle 设置文章置顶与精选
+// - 首页展示对全站生效，仅管理员可操作
+// - 截止时间须晚于当前时间，到期后由定时任务（ExpirePromotions）自动取消
+func (a *ArticleSvc) PromoteArticle(
+	ctx context.Context,
+	operatorID uint,
+	req request.ArticlePromoteReq,
+) error {
+	// 1、权限校验
+	isAdmin, err := a.permissionService.IsAdmin(ctx, operatorID)
+	if err != nil {
+		global.Log.Error("获取用户角色失败", zap.Uint("userID", operatorID), zap.Error(err))
+		return fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	if !isAdmin {
+		return ErrArticleForbidden
+	}
+	// 2、校验截止时间，取消置顶/精选时一并清空权重与截止时间
+	promotion := struct {
+		Pinned        bool    `json:"pinned"`
+		PinWeight     int     `json:"pin_weight"`
+		PinUntil      *string `json:"pin_until"` // 为 nil 时清空截止时间
+		Featured      bool    `json:"featured"`
+		FeatureWeight int     `json:"feature_weight"`
+		FeatureUntil  *string `json:"feature_until"` // 为 nil 时清空截止时间
+	}{Pinned: req.Pinned, Featured: req.Featured}
+	if req.Pinned {
+		promotion.PinWeight = req.PinWeight
+		if promotion.PinUntil, err = parsePromotionUntil(req.PinUntil); err != nil {
+			return err
+		}
+	}
+	if req.Featured {
+		promotion.FeatureWeight = req.FeatureWeight
+		if promotion.FeatureUntil, err = parsePromotionUntil(req.FeatureUntil); err != nil {
+			return err
+		}
+	}
+	// 3、文章须存在（确认 ES 中的数据已是最新）
+	if err = a.esOutboxSvc.DispatchArticle(ctx, req.ID); err != nil {
+		return err
+	}
+	if _, err = esUtil.Get(ctx, req.ID); err != nil {
+		global.Log.Warn("获取文章失败", zap.String("id", req.ID), zap.Error(err))
+		return fmt.Errorf("获取文章失败: %v", err)
+	}
+	// 4、登记更新文章，事务提交后同步到 ES
+	err = a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		return a.esOutboxSvc.Enqueue(ctx, tx, req.ID, consts.EsOutboxUpdate, promotion)
+	})
+	if err != nil {
+		return err
+	}
+	// 5、立即同步到 ES，失败时由定时任务重试
+	a.dispatchArticle(ctx, req.ID)
+	return nil
+}
+
+// parsePromotionUntil 校验置顶/精选截止时间，为空时返回 nil（长期有效）
+func parsePromotionUntil(until string) (*string, error) {
+	until = strings.TrimSpace(until)
+	if until == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", until, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 格式应为 2006-01-02 15:04:05", ErrPromotionInvalid)
+	}
+	if !t.After(time.Now()) {
+		return nil, fmt.Errorf("%w: 必须晚于当前时间", ErrPromotionInvalid)
+	}
+	return &until, nil
+}
+
+// ExpirePromotions 取消所有到期的置顶与精选，返回更新的文章数
+func (a *ArticleSvc) ExpirePromotions(ctx context.Context) (int64, error) {
+	now := time.Now().Format("2006-01-02 15:04:05")
+	var total int64
+	for _, p := range []struct{ flag, prefix string }{{"pinned", "pin"}, {"featured", "feature"}} {
+		// 1、查询条件：截止时间已到
+		query := &types.Query{Range: map[string]types.RangeQuery{
+			p.prefix + "_until": types.DateRangeQuery{Lte: &now},
+		}}
+		// 2、更新脚本：取消置顶/精选，清空权重与截止时间
+		source := fmt.Sprintf("ctx._source.%s = false; ctx._source.remove('%s_weight'); ctx._source.remove('%s_until')",
+			p.flag, p.prefix, p.prefix)
+		// 3、批量更新，版本冲突跳过的文章由下一轮定时任务继续处理
+		res, err := esUtil.UpdateByQuery(ctx, query, &types.Script{Source: &source})
+		if err != nil {
+			global.Log.Error("取消到期的置顶/精选失败", zap.String("field", p.flag), zap.Error(err))
+			return total, fmt.Errorf("取消到期的置顶/精选失败: %v", err)
+		}
+		total += res.Updated
+	}
+	return total, nil
+}
+
+const (
+	// defaultCarouselSize 首页轮播默认返回条数
+	defaultCarouselSize = 5
+	// maxCarouselSize 首页轮播最多返回条数
+	maxCarouselSize = 20
+)
+
+// Carousel 首页轮播：返回有封面的精选公开文章，按精选权重倒序，同权重按发布时间倒序
+// - 截止时间已到但尚未被定时任务取消的文章同样排除
+func (a *ArticleSvc) Carousel(
+	ctx context.Context,
+	info request.ArticleCarouselReq,
+) ([]resp.ArticleItemResp, error) {
+	// 1、返回条数
+	size := info.Size
+	if size < 1 {
+		size = defaultCarouselSize
+	}
+	if size > maxCarouselSize {
+		size = maxCarouselSize
+	}
+	// 2、构建查询请求
+	now, anyTerm := time.Now().Format("2006-01-02 15:04:05"), "*"
+	query := &types.Query{Bool: &types.BoolQuery{
+		Filter: []types.Query{
+			esModel.VisibleQuery(guestViewerID),
+			{Term: map[string]types.TermQuery{"featured": {Value: true}}},
+			// cover 为 text 字段，空字符串不产生词项，通配查询可过滤掉没有封面的文章
+			{Wildcard: map[string]types.WildcardQuery{"cover": {Value: &anyTerm}}},
+		},
+		MustNot: []types.Query{
+			{Range: map[string]types.RangeQuery{"feature_until": types.DateRangeQuery{Lte: &now}}},
+		},
+	}}
+	option := esModel.EsOption{
+		Index: esModel.ArticleIndex(),
+		Request: &search.Request{Query: query, Sort: []types.SortCombinations{
+			types.SortOptions{SortOptions: map[string]types.FieldSort{"feature_weight": {
+				Order: &sortorder.Desc, Missing: "_last", UnmappedType: &fieldtype.Integer,
+			}}},
+			types.SortOptions{SortOptions: map[string]types.FieldSort{"publish_at": {Order: &sortorder.Desc, Missing: "_last"}}},
+			types.SortOptions{SortOptions: map[string]types.FieldSort{"created_at": {Order: &sortorder.Desc}}},
+		}},
+		IncludeContent: false,
+	}
+	option.Page = 1
+	option.PageSize = size
+	// 3、查询
+	hits, _, err := esUtil.EsPagination(ctx, option)
+	if err != nil {
+		global.Log.Error("查询首页轮播失败", zap.Error(err))
+		return nil, fmt.Errorf("查询首页轮播失败: %v", err)
+	}
+	// 4、结果映射
+	items, err := resp.FromHits(hits, false)
+	if err != nil {
+		global.Log.Warn("结果映射失败", zap.Error(err))
+		return nil, fmt.Errorf("结果映射失败: %v", err)
+	}
+	return items, nil
+}
+
+// checkArticleOwner 校验用户是否可以修改文章：作者本人或管理员
+// - 早期未记录作者的文章仅管理员可修改
+func (a *ArticleSvc) checkArticleOwner(
+	ctx context.Context,
+	userID uint,
+	article esModel.Article,
+) error {
+	if userID != 0 && article.AuthorID == userID {
+		return nil
+	}
+	isAdmin, err := a.permissionService.IsAdmin(ctx, userID)
+	if err != nil {
+		global.Log.Error("获取用户角色失败", zap.Uint("userID", userID), zap.Error(err))
+		return fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	if !isAdmin {
+		return ErrArticleForbidden
+	}
+	return nil
+}
+
+// resolveArticleStatus 校验并解析文章状态与发布时间
+// - 未传状态按已发布处理，已发布未指定时间时取当前时间
+// - 定时发布必须指定一个将来的发布时间
+// - 草稿不保留发布时间（返回 nil）
+func resolveArticleStatus(
+	status consts.ArticleStatus,
+	publishAt string,
+) (consts.ArticleStatus, *string, error) {
+	// 1、解析发布时间
+	publishAt = strings.TrimSpace(publishAt)
+	var at time.Time
+	if publishAt != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", publishAt, time.Local)
+		if err != nil {
+			return status, nil, fmt.Errorf("发布时间格式错误: %v", err)
+		}
+		at = t
+	}
+	// 2、按状态处理
+	switch status {
+	case consts.ArticleStatusNone, consts.ArticlePublished:
+		if publishAt == "" {
+			publishAt = time.Now().Format("2006-01-02 15:04:05")
+		}
+		return consts.ArticlePublished, &publishAt, nil
+	case consts.ArticleScheduled:
+		if publishAt == "" {
+			return status, nil, fmt.Errorf("定时发布必须指定发布时间")
+		}
+		if !at.After(time.Now()) {
+			return status, nil, fmt.Errorf("定时发布时间必须晚于当前时间")
+		}
+		return status, &publishAt, nil
+	case consts.ArticleDraft:
+		return status, nil, nil
+	case consts.ArticleArchived:
+		if publishAt == "" {
+			return status, nil, nil
+		}
+		return status, &publishAt, nil
+	default:
+		return status, nil, fmt.Errorf("未知的文章状态: %d", status)
+	}
+}
+
 // updateCategoryCounts 分类计数调整：旧分类-1/删除，新分类+1/创建
 func (a *ArticleSvc) updateCategoryCounts(
 	ctx context.Context,
@@ -461,6 +1592,16 @@ func updateCoverCategory(
 	return nil
 }
 
+// renderContent 渲染文章正文（Markdown），生成过滤后的 HTML 与目录
+func renderContent(content string) (markdown.Document, error) {
+	rendered, err := markdown.Render(content)
+	if err != nil {
+		global.Log.Error("渲染文章内容失败", zap.Error(err))
+		return markdown.Document{}, fmt.Errorf("渲染文章内容失败: %v", err)
+	}
+	return rendered, nil
+}
+
 // updateIllustrationsCategory 插图类别更新
 func updateIllustrationsCategory(
 	ctx context.Context,
@@ -0,0 +1,374 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrTaxonomyForbidden 非管理员管理分类/标签
+var ErrTaxonomyForbidden = errors.New("仅管理员可以管理分类和标签")
+
+const (
+	// taxonomyRewriteAttempts 改写文章遇到版本冲突时的最多尝试次数
+	taxonomyRewriteAttempts = 3
+	// renameCategoryScript 将文章分类改为新分类
+	renameCategoryScript = `ctx._source.category = params.to;`
+	// mergeTagsScript 将文章中属于 sources 的标签替换为 target，并去重
+	mergeTagsScript = `
+if (ctx._source.tags == null) { return; }
+List merged = new ArrayList();
+for (def tag : ctx._source.tags) {
+  def name = params.sources.contains(tag) ? params.target : tag;
+  if (!merged.contains(name)) { merged.add(name); }
+}
+ctx._source.tags = merged;`
+)
+
+// ArticleTaxonomySvc 文章分类与标签管理服务
+// - 列表读取 MySQL 中维护的计数
+// - 重命名/合并需同时改写 ES 中的文章与 MySQL 中的计数，仅管理员可操作
+// - 改写前先清空 ES 同步发件箱，避免之后同步的旧文档把分类/标签写回旧值
+type ArticleTaxonomySvc struct {
+	articleRepo       interfaces.ArticleRepository
+	permissionService *PermissionService
+	esOutboxSvc       *EsOutboxSvc
+}
+
+// NewArticleTaxonomySvc 创建文章分类与标签管理服务实例
+func NewArticleTaxonomySvc(
+	group *repository.Group,
+	permissionService *PermissionService,
+	esOutboxSvc *EsOutboxSvc,
+) *ArticleTaxonomySvc {
+	return &ArticleTaxonomySvc{
+		articleRepo:       group.SystemRepositorySupplier.GetArticleRepository(),
+		permissionService: permissionService,
+		esOutboxSvc:       esOutboxSvc,
+	}
+}
+
+// CategoryList 分类列表（含文章数）
+func (a *ArticleTaxonomySvc) CategoryList(
+	ctx context.Context,
+	req request.ArticleTaxonomyListReq,
+) ([]resp.CategoryCountResp, error) {
+	categories, err := a.articleRepo.ListCategories(ctx, taxonomyByName(req.Sort), req.Limit)
+	if err != nil {
+		global.Log.Error("查询分类列表失败", zap.Error(err))
+		return nil, fmt.Errorf("查询分类列表失败: %v", err)
+	}
+	list := make([]resp.CategoryCountResp, 0, len(categories))
+	for _, c := range categories {
+		list = append(list, resp.CategoryCountResp{Category: c.Category, Number: int64(c.Number)})
+	}
+	return list, nil
+}
+
+// TagList 标签列表（含文章数），用于标签云
+func (a *ArticleTaxonomySvc) TagList(
+	ctx context.Context,
+	req request.ArticleTaxonomyListReq,
+) ([]resp.TagCountResp, error) {
+	tags, err := a.articleRepo.ListTags(ctx, taxonomyByName(req.Sort), req.Limit)
+	if err != nil {
+		global.Log.Error("查询标签列表失败", zap.Error(err))
+		return nil, fmt.Errorf("查询标签列表失败: %v", err)
+	}
+	list := make([]resp.TagCountResp, 0, len(tags))
+	for _, t := range tags {
+		list = append(list, resp.TagCountResp{Tag: t.Tag, Number: int64(t.Number)})
+	}
+	return list, nil
+}
+
+// RenameCategory 重命名分类，目标分类已存在时合并，返回被改写的文章数
+// - 发件箱中还有未同步的文章时返回 ErrArticleSyncing，不做任何修改
+// - 在事务中先调整 MySQL 计数，再通过 update_by_query 改写 ES 文档；ES 失败或仍有文章未改写时计数回滚，重新执行即可继续改写剩余文章
+func (a *ArticleTaxonomySvc) RenameCategory(
+	ctx context.Context,
+	operatorID uint,
+	req request.CategoryRenameReq,
+) (updated int64, err error) {
+	// 1、权限校验
+	if err = a.checkAdmin(ctx, operatorID); err != nil {
+		return 0, err
+	}
+	// 2、参数校验
+	from, to := strings.TrimSpace(req.From), strings.TrimSpace(req.To)
+	if from == "" || to == "" {
+		return 0, fmt.Errorf("分类不能为空")
+	}
+	if from == to {
+		return 0, fmt.Errorf("新旧分类相同")
+	}
+	// 3、构建改写脚本
+	toRaw, err := json.Marshal(to)
+	if err != nil {
+		return 0, err
+	}
+	source := renameCategoryScript
+	script := &types.Script{Source: &source, Params: map[string]json.RawMessage{"to": toRaw}}
+	query := &types.Query{Term: map[string]types.TermQuery{"category": {Value: from}}}
+	// 4、清空发件箱
+	if err = a.esOutboxSvc.DispatchAll(ctx); err != nil {
+		return 0, err
+	}
+	// 5、在事务中调整计数并改写文章
+	err = a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 5.a 合并计数
+		if err := a.articleRepo.RenameCategory(ctx, tx, from, to); err != nil {
+			global.Log.Error("更新分类计数失败",
+				zap.String("from", from), zap.String("to", to), zap.Error(err))
+			return fmt.Errorf("更新分类计数失败: %v", err)
+		}
+		// 5.b 改写文章
+		var err error
+		if updated, err = rewriteArticles(ctx, query, script); err != nil {
+			global.Log.Error("改写文章分类失败",
+				zap.String("from", from), zap.String("to", to), zap.Int64("updated", updated), zap.Error(err))
+			return fmt.Errorf("改写文章分类失败: %w", err)
+		}
+		return nil
+	})
+	if updated > 0 {
+		invalidatePublicCache()
+	}
+	return updated, err
+}
+
+// MergeTags 将多个标签合并为一个标签（单个标签时即为重命名），返回被改写的文章数
+// - 同一篇文章同时含有多个被合并的标签时只保留一个目标标签，目标标签的计数按 ES 中的实际文章数重算
+// - 发件箱与失败回滚的处理与 RenameCategory 相同
+func (a *ArticleTaxonomySvc) MergeTags(
+	ctx context.Context,
+	operatorID uint,
+	req request.TagMergeReq,
+) (updated int64, err error) {
+	// 1、权限校验
+	if err = a.checkAdmin(ctx, operatorID); err != nil {
+		return 0, err
+	}
+	// 2、参数校验：去除空白、重复以及与目标相同的标签
+	target := strings.TrimSpace(req.Target)
+	if target == "" {
+		return 0, fmt.Errorf("目标标签不能为空")
+	}
+	sources := make([]string, 0, len(req.Sources))
+	seen := map[string]bool{target: true}
+	for _, tag := range req.Sources {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		sources = append(sources, tag)
+	}
+	if len(sources) == 0 {
+		return 0, fmt.Errorf("没有需要合并的标签")
+	}
+	// 3、清空发件箱，保证统计与改写的都是最新的文章
+	if err = a.esOutboxSvc.DispatchAll(ctx); err != nil {
+		return 0, err
+	}
+	// 4、合并后目标标签的文章数 = 含有任一被合并标签或目标标签的文章数
+	all := append(append([]string{}, sources...), target)
+	number, err := esUtil.Count(ctx, &types.Query{Terms: &types.TermsQuery{
+		TermsQuery: map[string]types.TermsQueryField{"tags": all},
+	}})
+	if err != nil {
+		global.Log.Error("统计标签文章数失败", zap.Strings("tags", all), zap.Error(err))
+		return 0, fmt.Errorf("统计标签文章数失败: %v", err)
+	}
+	// 5、构建改写脚本
+	sourcesRaw, err := json.Marshal(sources)
+	if err != nil {
+		return 0, err
+	}
+	targetRaw, err := json.Marshal(target)
+	if err != nil {
+		return 0, err
+	}
+	source := mergeTagsScript
+	script := &types.Script{Source: &source, Params: map[string]json.RawMessage{
+		"sources": sourcesRaw,
+		"target":  targetRaw,
+	}}
+	query := &types.Query{Terms: &types.TermsQuery{
+		TermsQuery: map[string]types.TermsQueryField{"tags": sources},
+	}}
+	// 6、在事务中调整计数并改写文章
+	err = a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		// 6.a 删除被合并标签，重置目标标签计数
+		if err := a.articleRepo.MergeTags(ctx, tx, sources, target, int(number)); err != nil {
+			global.Log.Error("更新标签计数失败",
+				zap.Strings("sources", sources), zap.String("target", target), zap.Error(err))
+			return fmt.Errorf("更新标签计数失败: %v", err)
+		}
+		// 6.b 改写文章
+		var err error
+		if updated, err = rewriteArticles(ctx, query, script); err != nil {
+			global.Log.Error("改写文章标签失败",
+				zap.Strings("sources", sources), zap.String("target", target), zap.Int64("updated", updated), zap.Error(err))
+			return fmt.Errorf("改写文章标签失败: %w", err)
+		}
+		return nil
+	})
+	if updated > 0 {
+		invalidatePublicCache()
+	}
+	return updated, err
+}
+
+// rewriteArticles 通过 update_by_query 改写文章，返回改写的文章数
+// - 已改写的文章不再命中查询，遇到版本冲突（如同时有浏览量、收藏数写入）或超时时重新执行，只处理剩余文章
+// - 多次尝试后仍有文章未改写时返回 ErrArticleSyncing，出现无法恢复的失败时返回错误
+func rewriteArticles(ctx context.Context, query *types.Query, script *types.Script) (int64, error) {
+	var updated int64
+	for attempt := 1; ; attempt++ {
+		res, err := esUtil.UpdateByQuery(ctx, query, script)
+		if err != nil {
+			return updated, err
+		}
+		updated += res.Updated
+		if len(res.Failures) > 0 {
+			return updated, fmt.Errorf("%d 篇文章改写失败: %s", len(res.Failures), strings.Join(res.Failures, "; "))
+		}
+		if res.Complete() {
+			return updated, nil
+		}
+		if attempt >= taxonomyRewriteAttempts {
+			return updated, fmt.Errorf("%w: %d 篇文章改写时发生版本冲突或超时", ErrArticleSyncing, res.VersionConflicts)
+		}
+	}
+}
+
+// Reconcile 分类/标签计数对账：以 ES 词项聚合的实际文章数为准，找出 MySQL 计数的偏差
+// - repair 为 true 时在一个事务中把偏差写回 article_categories 与 article_tags
+// - ES 统计使用组合聚合读取全部词项，结果不完整时直接返回错误，不会按残缺结果删除计数行
+// - 对账期间新建/删除的文章可能造成少量偏差，由下一次对账修正
+func (a *ArticleTaxonomySvc) Reconcile(ctx context.Context, repair bool) (res resp.ReconcileResp, err error) {
+	// 1、从 ES 统计全部文章（含草稿、私密）的分类与标签
+	esCategories, err := esUtil.TermsCountAll(ctx, nil, "category")
+	if err != nil {
+		global.Log.Error("统计分类失败", zap.Error(err))
+		return res, fmt.Errorf("统计分类失败: %v", err)
+	}
+	esTags, err := esUtil.TermsCountAll(ctx, nil, "tags")
+	if err != nil {
+		global.Log.Error("统计标签失败", zap.Error(err))
+		return res, fmt.Errorf("统计标签失败: %v", err)
+	}
+	// 2、读取 MySQL 计数
+	categories, err := a.articleRepo.ListCategories(ctx, true, 0)
+	if err != nil {
+		global.Log.Error("查询分类列表失败", zap.Error(err))
+		return res, fmt.Errorf("查询分类列表失败: %v", err)
+	}
+	tags, err := a.articleRepo.ListTags(ctx, true, 0)
+	if err != nil {
+		global.Log.Error("查询标签列表失败", zap.Error(err))
+		return res, fmt.Errorf("查询标签列表失败: %v", err)
+	}
+	// 3、对比计数
+	actualCategories := make(map[string]int64, len(categories))
+	for _, c := range categories {
+		actualCategories[c.Category] = int64(c.Number)
+	}
+	actualTags := make(map[string]int64, len(tags))
+	for _, t := range tags {
+		actualTags[t.Tag] = int64(t.Number)
+	}
+	res.Categories = taxonomyDrifts(esCategories, actualCategories)
+	res.Tags = taxonomyDrifts(esTags, actualTags)
+	if !repair || (len(res.Categories) == 0 && len(res.Tags) == 0) {
+		return res, nil
+	}
+	// 4、修复：按 ES 的实际文章数重置计数
+	err = a.articleRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		for _, d := range res.Categories {
+			if err := a.articleRepo.SetCategoryNumber(ctx, tx, d.Name, int(d.Expected)); err != nil {
+				global.Log.Error("修复分类计数失败", zap.String("category", d.Name), zap.Error(err))
+				return fmt.Errorf("修复分类计数失败: %v", err)
+			}
+		}
+		for _, d := range res.Tags {
+			if err := a.articleRepo.SetTagNumber(ctx, tx, d.Name, int(d.Expected)); err != nil {
+				global.Log.Error("修复标签计数失败", zap.String("tag", d.Name), zap.Error(err))
+				return fmt.Errorf("修复标签计数失败: %v", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	res.Repaired = true
+	return res, nil
+}
+
+// taxonomyDrifts 对比 ES 实际文章数与 MySQL 计数，返回存在偏差的条目（按名称升序）
+func taxonomyDrifts(expected []esUtil.TermBucket, actual map[string]int64) []resp.TaxonomyDriftResp {
+	drifts := make([]resp.TaxonomyDriftResp, 0)
+	seen := make(map[string]bool, len(expected))
+	// 1、ES 中存在的词项
+	for _, b := range expected {
+		if b.Key == "" {
+			continue
+		}
+		seen[b.Key] = true
+		if actual[b.Key] != b.Count {
+			drifts = append(drifts, resp.TaxonomyDriftResp{Name: b.Key, Expected: b.Count, Actual: actual[b.Key]})
+		}
+	}
+	// 2、只存在于 MySQL 中的词项，实际文章数为 0
+	for name, number := range actual {
+		if !seen[name] {
+			drifts = append(drifts, resp.TaxonomyDriftResp{Name: name, Expected: 0, Actual: number})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Name < drifts[j].Name })
+	return drifts
+}
+
+// checkAdmin 校验用户是否为管理员
+func (a *ArticleTaxonomySvc) checkAdmin(ctx context.Context, userID uint) error {
+	isAdmin, err := a.permissionService.IsAdmin(ctx, userID)
+	if err != nil {
+		global.Log.Error("获取用户角色失败", zap.Uint("userID", userID), zap.Error(err))
+		return fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	if !isAdmin {
+		return ErrTaxonomyForbidden
+	}
+	return nil
+}
+
+// taxonomyByName 是否按名称排序：sort=name 时按名称升序，其余按文章数倒序
+func taxonomyByName(sortBy string) bool {
+	return strings.EqualFold(strings.TrimSpace(sortBy), "name")
+}
+
+// arrangeTermBuckets 对聚合分桶按请求排序并截断（ES 返回的分桶已按文章数倒序）
+func arrangeTermBuckets(buckets []esUtil.TermBucket, req request.ArticleTaxonomyListReq) []esUtil.TermBucket {
+	if taxonomyByName(req.Sort) {
+		sort.Slice(buckets, func(i, j int) bool { return buckets[i].Key < buckets[j].Key })
+	}
+	if req.Limit > 0 && len(buckets) > req.Limit {
+		buckets = buckets[:req.Limit]
+	}
+	return buckets
+}
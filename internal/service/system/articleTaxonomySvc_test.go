@@ -0,0 +1,66 @@
+package system
+
+import (
+	resp "personal_blog/internal/model/dto/response"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"reflect"
+	"testing"
+)
+
+func TestTaxonomyDrifts(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []esUtil.TermBucket
+		actual   map[string]int64
+		want     []resp.TaxonomyDriftResp
+	}{
+		{
+			name:     "都为空",
+			expected: nil,
+			actual:   map[string]int64{},
+			want:     []resp.TaxonomyDriftResp{},
+		},
+		{
+			name:     "计数一致",
+			expected: []esUtil.TermBucket{{Key: "go", Count: 2}, {Key: "java", Count: 1}},
+			actual:   map[string]int64{"go": 2, "java": 1},
+			want:     []resp.TaxonomyDriftResp{},
+		},
+		{
+			name:     "计数偏差",
+			expected: []esUtil.TermBucket{{Key: "go", Count: 3}},
+			actual:   map[string]int64{"go": 1},
+			want:     []resp.TaxonomyDriftResp{{Name: "go", Expected: 3, Actual: 1}},
+		},
+		{
+			name:     "只存在于 ES",
+			expected: []esUtil.TermBucket{{Key: "rust", Count: 1}},
+			actual:   map[string]int64{},
+			want:     []resp.TaxonomyDriftResp{{Name: "rust", Expected: 1, Actual: 0}},
+		},
+		{
+			name:     "只存在于 MySQL",
+			expected: []esUtil.TermBucket{{Key: "go", Count: 1}},
+			actual:   map[string]int64{"go": 1, "php": 4},
+			want:     []resp.TaxonomyDriftResp{{Name: "php", Expected: 0, Actual: 4}},
+		},
+		{
+			name:     "忽略空词项并按名称排序",
+			expected: []esUtil.TermBucket{{Key: "", Count: 5}, {Key: "b", Count: 1}, {Key: "a", Count: 2}},
+			actual:   map[string]int64{"c": 1},
+			want: []resp.TaxonomyDriftResp{
+				{Name: "a", Expected: 2, Actual: 0},
+				{Name: "b", Expected: 1, Actual: 0},
+				{Name: "c", Expected: 0, Actual: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taxonomyDrifts(tt.expected, tt.actual)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taxonomyDrifts() = %+v, 期望 %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package system
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"personal_blog/global"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"personal_blog/pkg/util"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// articleViewDeltaKey 浏览量增量哈希：field 为文章ID，value 为尚未刷入 ES 的增量
+	articleViewDeltaKey = "article:view:delta"
+	// articleViewFlushingKey 刷新过程中的增量快照，避免与新写入的增量互相覆盖
+	articleViewFlushingKey = "article:view:flushing"
+	// articleViewDedupPrefix 访客去重键前缀：article:view:dedup:<文章ID>:<访客标识>
+	articleViewDedupPrefix = "article:view:dedup:"
+	// defaultViewDedupWindow 未配置去重窗口时的默认值
+	defaultViewDedupWindow = 30 * time.Minute
+)
+
+// ArticleViewSvc 文章浏览量服务
+// - 浏览先记录在 Redis 中（按访客 + 时间窗口去重），由定时任务批量刷入 ES
+type ArticleViewSvc struct{}
+
+func NewArticleViewSvc() *ArticleViewSvc {
+	return &ArticleViewSvc{}
+}
+
+// ViewVisitor 生成访客标识：登录用户使用用户ID，游客使用 IP + UA 的摘要
+func (v *ArticleViewSvc) ViewVisitor(userID uint, ip, userAgent string) string {
+	if userID != 0 {
+		return "u:" + strconv.FormatUint(uint64(userID), 10)
+	}
+	sum := sha1.Sum([]byte(ip + "|" + userAgent))
+	return "g:" + hex.EncodeToString(sum[:])
+}
+
+// RecordView 记录一次文章浏览
+// - 同一访客在去重窗口内重复浏览只计一次
+func (v *ArticleViewSvc) RecordView(
+	ctx context.Context,
+	articleID string,
+	visitor string,
+) error {
+	// 1、去重：窗口内首次浏览才会设置成功
+	dedupKey := articleViewDedupPrefix + articleID + ":" + visitor
+	first, err := global.Redis.SetNX(dedupKey, 1, viewDedupWindow()).Result()
+	if err != nil {
+		return fmt.Errorf("记录浏览去重失败: %v", err)
+	}
+	if !first {
+		return nil
+	}
+	// 2、累加增量，等待定时任务刷入 ES
+	if err = global.Redis.HIncrBy(articleViewDeltaKey, articleID, 1).Err(); err != nil {
+		return fmt.Errorf("累加浏览增量失败: %v", err)
+	}
+	return nil
+}
+
+// FlushViews 将 Redis 中累计的浏览增量批量刷入 ES，返回成功刷入的文章数
+func (v *ArticleViewSvc) FlushViews(ctx context.Context) (int, error) {
+	// 1、上次刷新中断遗留的快照，先行处理
+	exists, err := global.Redis.Exists(articleViewFlushingKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("检查浏览增量快照失败: %v", err)
+	}
+	if exists == 0 {
+		// 1.a 没有待刷新的增量，直接返回
+		pending, err := global.Redis.Exists(articleViewDeltaKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("检查浏览增量失败: %v", err)
+		}
+		if pending == 0 {
+			return 0, nil
+		}
+		// 1.b 将增量原子地转为快照，新的浏览继续写入新的增量哈希
+		if err = global.Redis.Rename(articleViewDeltaKey, articleViewFlushingKey).Err(); err != nil {
+			return 0, fmt.Errorf("生成浏览增量快照失败: %v", err)
+		}
+	}
+	// 2、读取快照
+	raw, err := global.Redis.HGetAll(articleViewFlushingKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("读取浏览增量快照失败: %v", err)
+	}
+	deltas := make(map[string]int, len(raw))
+	for id, val := range raw {
+		n, err := strconv.Atoi(val)
+		if err != nil || n == 0 {
+			continue
+		}
+		deltas[id] = n
+	}
+	// 3、批量刷入 ES
+	failed, err := esUtil.BulkIncrField(ctx, "views", deltas)
+	if err != nil {
+		// 3.a 整体失败：保留快照，下次刷新重试
+		global.Log.Error("浏览量刷入ES失败", zap.Int("count", len(deltas)), zap.Error(err))
+		return 0, fmt.Errorf("浏览量刷入ES失败: %v", err)
+	}
+	// 3.b 部分失败：把失败的增量回补到增量哈希中
+	for id, delta := range failed {
+		if err = global.Redis.HIncrBy(articleViewDeltaKey, id, int64(delta)).Err(); err != nil {
+			global.Log.Error("回补浏览增量失败",
+				zap.String("id", id), zap.Int("delta", delta), zap.Error(err))
+		}
+	}
+	// 4、删除快照
+	if err = global.Redis.Del(articleViewFlushingKey).Err(); err != nil {
+		return 0, fmt.Errorf("删除浏览增量快照失败: %v", err)
+	}
+	return len(deltas) - len(failed), nil
+}
+
+// viewDedupWindow 解析配置中的去重窗口，解析失败时使用默认值
+func viewDedupWindow() time.Duration {
+	window, err := util.ParseDuration(global.Config.Article.ViewDedupWindow)
+	if err != nil || window <= 0 {
+		return defaultViewDedupWindow
+	}
+	return window
+}
@@ -0,0 +1,176 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CommentSvc 评论服务
+type CommentSvc struct {
+	commentRepo       interfaces.CommentRepository
+	permissionService *PermissionService
+}
+
+// NewCommentSvc 创建评论服务实例
+func NewCommentSvc(
+	group *repository.Group,
+	permissionService *PermissionService,
+) *CommentSvc {
+	return &CommentSvc{
+		commentRepo:       group.SystemRepositorySupplier.GetCommentRepository(),
+		permissionService: permissionService,
+	}
+}
+
+// CommentCreate 发表评论或回复
+func (c *CommentSvc) CommentCreate(
+	ctx context.Context,
+	userID uint,
+	req *request.CommentCreateReq,
+) (*entity.Comment, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, errors.New("评论内容不能为空")
+	}
+	// 1、校验文章是否存在且对当前用户可见
+	if err := checkArticleVisible(ctx, req.ArticleID, userID); err != nil {
+		return nil, err
+	}
+	comment := &entity.Comment{
+		ArticleID: req.ArticleID,
+		UserID:    userID,
+		Content:   content,
+		Status:    consts.CommentNormal,
+	}
+	// 2、回复评论时，校验父评论并确定根评论
+	if req.ParentID != 0 {
+		parent, err := c.commentRepo.GetByID(ctx, req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("获取父评论失败: %v", err)
+		}
+		if parent == nil || parent.ArticleID != req.ArticleID {
+			return nil, errors.New("回复的评论不存在")
+		}
+		comment.ParentID = parent.ID
+		comment.RootID = parent.RootID
+		if comment.RootID == 0 {
+			comment.RootID = parent.ID
+		}
+	}
+	// 3、在事物中创建评论，并同步文章评论计数
+	err := c.commentRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := c.commentRepo.Create(ctx, tx, comment); err != nil {
+			global.Log.Error("创建评论失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+			return fmt.Errorf("创建评论失败: %v", err)
+		}
+		if err := esUtil.IncrField(ctx, req.ArticleID, "comments", 1); err != nil {
+			global.Log.Error("更新文章评论数失败", zap.String("article_id", req.ArticleID), zap.Error(err))
+			return fmt.Errorf("更新文章评论数失败: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetCommentList 评论列表（按顶级评论分页，附带整楼回复）
+// - 文章须对 viewerID 可见；viewerID 为 0 表示游客
+func (c *CommentSvc) GetCommentList(
+	ctx context.Context,
+	viewerID uint,
+	req request.CommentListReq,
+) (res resp.CommentListResp, err error) {
+	// 0、校验文章是否存在且对当前用户可见
+	if err = checkArticleVisible(ctx, req.ArticleID, viewerID); err != nil {
+		return res, err
+	}
+	// 1、分页参数
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	// 2、查询顶级评论
+	roots, total, err := c.commentRepo.ListRoots(ctx, req.ArticleID, page, pageSize)
+	if err != nil {
+		return res, fmt.Errorf("获取评论失败: %v", err)
+	}
+	// 3、查询这些顶级评论下的全部回复
+	rootIDs := make([]uint, 0, len(roots))
+	for _, r := range roots {
+		rootIDs = append(rootIDs, r.ID)
+	}
+	replies, err := c.commentRepo.ListReplies(ctx, rootIDs)
+	if err != nil {
+		return res, fmt.Errorf("获取回复失败: %v", err)
+	}
+	// 4、组装评论树
+	return resp.CommentListResp{
+		List:       resp.BuildCommentTree(roots, replies),
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// CommentDelete 删除评论（本人或管理员），其下回复一并删除
+func (c *CommentSvc) CommentDelete(
+	ctx context.Context,
+	userID uint,
+	req *request.CommentDeleteReq,
+) error {
+	// 1、获取评论
+	comment, err := c.commentRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return fmt.Errorf("获取评论失败: %v", err)
+	}
+	if comment == nil {
+		return errors.New("评论不存在")
+	}
+	// 2、权限校验：评论作者或管理员
+	if comment.UserID != userID {
+		isAdmin, err := c.permissionService.IsAdmin(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("获取用户角色失败: %v", err)
+		}
+		if !isAdmin {
+			return errors.New("无权限删除 其他用户的评论")
+		}
+	}
+	// 3、在事物中删除评论，并同步文章评论计数
+	return c.commentRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		deleted, err := c.commentRepo.DeleteWithReplies(ctx, tx, comment.ID)
+		if err != nil {
+			global.Log.Error("删除评论失败", zap.Uint("id", comment.ID), zap.Error(err))
+			return fmt.Errorf("删除评论失败: %v", err)
+		}
+		if deleted == 0 {
+			return nil
+		}
+		if err = esUtil.IncrField(ctx, comment.ArticleID, "comments", -int(deleted)); err != nil {
+			global.Log.Error("更新文章评论数失败",
+				zap.String("article_id", comment.ArticleID), zap.Error(err))
+			return fmt.Errorf("更新文章评论数失败: %v", err)
+		}
+		return nil
+	})
+}
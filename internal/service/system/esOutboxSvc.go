@@ -0,0 +1,309 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	"personal_blog/internal/model/entity"
+	"personal_blog/internal/repository"
+	"personal_blog/internal/repository/interfaces"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"personal_blog/pkg/storage"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrArticleSyncing 文章还有尚未同步到 ES 的操作
+	ErrArticleSyncing = errors.New("文章正在同步中，请稍后重试")
+	// ErrEsOutboxForbidden 非管理员管理 ES 同步死信
+	ErrEsOutboxForbidden = errors.New("仅管理员可以管理ES同步死信")
+)
+
+const (
+	// defaultOutboxAttempts 未配置时单条操作的最多尝试次数
+	defaultOutboxAttempts = 10
+	// outboxBatchSize 后台任务每轮处理的最大条数
+	outboxBatchSize = 100
+	// outboxLease 抢占后的租约时长，执行者中途退出时租约到期可被重新抢占
+	outboxLease = time.Minute
+	// outboxRetryBase 首次失败后的重试间隔，之后按指数增长
+	outboxRetryBase = 10 * time.Second
+	// outboxRetryMax 重试间隔上限
+	outboxRetryMax = 10 * time.Minute
+)
+
+// EsOutboxSvc ES 同步发件箱服务
+// - 业务事务中只登记 ES 操作，事务提交后立即尝试同步，失败的由定时任务按退避策略重试
+// - 同一文章的操作严格按登记顺序执行，前一条未成功时后续操作不会执行；前一条成为死信时文章保持阻塞，直到死信重新入队并同步成功
+type EsOutboxSvc struct {
+	outboxRepo        interfaces.EsOutboxRepository
+	permissionService *PermissionService
+}
+
+// NewEsOutboxSvc 创建 ES 同步发件箱服务实例
+func NewEsOutboxSvc(group *repository.Group, permissionService *PermissionService) *EsOutboxSvc {
+	return &EsOutboxSvc{
+		outboxRepo:        group.SystemRepositorySupplier.GetEsOutboxRepository(),
+		permissionService: permissionService,
+	}
+}
+
+// Enqueue 在业务事务中登记一次 ES 操作，doc 为写入/更新的文档内容（删除时为 nil）
+func (s *EsOutboxSvc) Enqueue(
+	ctx context.Context,
+	tx *gorm.DB,
+	articleID string,
+	op consts.EsOutboxOp,
+	doc any,
+) error {
+	outbox := &entity.EsOutbox{
+		ArticleID:   articleID,
+		Op:          op,
+		Status:      consts.EsOutboxPending,
+		NextRetryAt: time.Now(),
+	}
+	if doc != nil {
+		payload, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("序列化文档失败: %v", err)
+		}
+		outbox.Payload = string(payload)
+	}
+	if err := s.outboxRepo.Create(ctx, tx, outbox); err != nil {
+		global.Log.Error("登记ES同步操作失败",
+			zap.String("article_id", articleID), zap.String("op", op.String()), zap.Error(err))
+		return fmt.Errorf("登记ES同步操作失败: %v", err)
+	}
+	return nil
+}
+
+// DispatchArticle 立即同步文章的全部待同步操作（业务事务提交后调用）
+// - 仍有操作未同步（失败、已成为死信或正在由其他执行者处理）时返回 ErrArticleSyncing
+// - 遇到死信即停止：死信重新入队并同步成功之前，文章的后续操作都不会执行
+func (s *EsOutboxSvc) DispatchArticle(ctx context.Context, articleID string) error {
+	list, err := s.outboxRepo.ListByArticleID(ctx, articleID)
+	if err != nil {
+		global.Log.Error("查询ES同步操作失败", zap.String("article_id", articleID), zap.Error(err))
+		return fmt.Errorf("查询ES同步操作失败: %v", err)
+	}
+	for _, outbox := range list {
+		if outbox.Status == consts.EsOutboxDead {
+			return ErrArticleSyncing
+		}
+		ok, err := s.process(ctx, outbox)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrArticleSyncing
+		}
+	}
+	return nil
+}
+
+// DispatchAll 立即同步全部文章的未完成操作，用于批量改写 ES 之前清空发件箱
+// - 任一文章仍有操作未同步（失败、已成为死信或正在由其他执行者处理）时返回 ErrArticleSyncing
+func (s *EsOutboxSvc) DispatchAll(ctx context.Context) error {
+	ids, err := s.outboxRepo.ListArticleIDs(ctx)
+	if err != nil {
+		global.Log.Error("查询ES同步操作失败", zap.Error(err))
+		return fmt.Errorf("查询ES同步操作失败: %v", err)
+	}
+	var blocked []string
+	for _, id := range ids {
+		if err = s.DispatchArticle(ctx, id); errors.Is(err, ErrArticleSyncing) {
+			blocked = append(blocked, id)
+		} else if err != nil {
+			return err
+		}
+	}
+	if len(blocked) > 0 {
+		return fmt.Errorf("%w: %d 篇文章尚未同步，如有死信请先处理", ErrArticleSyncing, len(blocked))
+	}
+	return nil
+}
+
+// DispatchDue 同步所有已到执行时间的操作，返回成功同步的文章数
+// - 每篇文章先执行最早的一条操作，成功后接着同步该文章的后续操作，保证执行顺序
+func (s *EsOutboxSvc) DispatchDue(ctx context.Context) (int, error) {
+	list, err := s.outboxRepo.ListDue(ctx, time.Now(), outboxBatchSize)
+	if err != nil {
+		global.Log.Error("查询ES同步操作失败", zap.Error(err))
+		return 0, fmt.Errorf("查询ES同步操作失败: %v", err)
+	}
+	done := 0
+	for _, outbox := range list {
+		ok, err := s.process(ctx, outbox)
+		if err != nil {
+			return done, err
+		}
+		if !ok {
+			continue
+		}
+		done++
+		// 后续操作失败时留待下一轮，不影响其他文章
+		if err = s.DispatchArticle(ctx, outbox.ArticleID); err != nil && !errors.Is(err, ErrArticleSyncing) {
+			return done, err
+		}
+	}
+	return done, nil
+}
+
+// DeadList 分页查询死信（仅管理员）
+func (s *EsOutboxSvc) DeadList(
+	ctx context.Context,
+	operatorID uint,
+	req request.EsOutboxDeadListReq,
+) (res resp.EsOutboxListResp, err error) {
+	// 1、权限校验
+	if err = s.checkAdmin(ctx, operatorID); err != nil {
+		return res, err
+	}
+	// 1.a 分页参数
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	// 2、查询死信
+	list, total, err := s.outboxRepo.ListByStatus(ctx, consts.EsOutboxDead, page, pageSize)
+	if err != nil {
+		global.Log.Error("查询ES同步死信失败", zap.Error(err))
+		return res, fmt.Errorf("查询ES同步死信失败: %v", err)
+	}
+	// 3、结果映射
+	items := make([]resp.EsOutboxItemResp, 0, len(list))
+	for _, o := range list {
+		items = append(items, resp.FromEsOutbox(o))
+	}
+	return resp.EsOutboxListResp{
+		List:       items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// Requeue 将死信重新放回待同步队列（仅管理员），由定时任务重新同步，返回恢复的条数
+// - 死信之后的操作一直处于阻塞状态，重新入队后死信仍是文章最早的操作，会先于后续操作执行
+func (s *EsOutboxSvc) Requeue(
+	ctx context.Context,
+	operatorID uint,
+	req request.EsOutboxRequeueReq,
+) (int64, error) {
+	if err := s.checkAdmin(ctx, operatorID); err != nil {
+		return 0, err
+	}
+	count, err := s.outboxRepo.Requeue(ctx, req.IDs, time.Now())
+	if err != nil {
+		global.Log.Error("死信重新入队失败", zap.Uints("ids", req.IDs), zap.Error(err))
+		return 0, fmt.Errorf("死信重新入队失败: %v", err)
+	}
+	return count, nil
+}
+
+// process 抢占并执行一条操作，返回是否已同步成功
+// - 未抢占到（正在由其他执行者处理或尚未到重试时间）或执行失败时返回 false
+// - 只有读写发件箱本身出错时才返回 error
+func (s *EsOutboxSvc) process(ctx context.Context, outbox *entity.EsOutbox) (bool, error) {
+	// 1、抢占
+	now := time.Now()
+	claimed, err := s.outboxRepo.Claim(ctx, outbox.ID, now, now.Add(outboxLease))
+	if err != nil {
+		global.Log.Error("抢占ES同步操作失败", zap.Uint("id", outbox.ID), zap.Error(err))
+		return false, fmt.Errorf("抢占ES同步操作失败: %v", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+	// 2、执行，短暂故障在本次执行内退避重试
+	applyErr := storage.DoWithBackoff(ctx, 2, 200*time.Millisecond, 100*time.Millisecond, func() error {
+		return applyEsOutbox(ctx, outbox)
+	})
+	// 3、成功：删除记录，文章内容已变化，订阅源等公开缓存随之失效
+	if applyErr == nil {
+		invalidatePublicCache()
+		if err = s.outboxRepo.Delete(ctx, outbox.ID); err != nil {
+			global.Log.Error("删除ES同步操作失败", zap.Uint("id", outbox.ID), zap.Error(err))
+			return false, fmt.Errorf("删除ES同步操作失败: %v", err)
+		}
+		return true, nil
+	}
+	// 4、失败：记录原因，按退避时间等待下次重试，次数耗尽进入死信
+	attempts := outbox.Attempts + 1
+	status := consts.EsOutboxPending
+	if attempts >= outboxMaxAttempts() {
+		status = consts.EsOutboxDead
+	}
+	global.Log.Warn("同步ES操作失败",
+		zap.Uint("id", outbox.ID),
+		zap.String("article_id", outbox.ArticleID),
+		zap.String("op", outbox.Op.String()),
+		zap.Int("attempts", attempts),
+		zap.String("status", status.String()),
+		zap.Error(applyErr))
+	if err = s.outboxRepo.MarkFailed(ctx, outbox.ID, status, attempts, now.Add(outboxRetryDelay(attempts)), applyErr.Error()); err != nil {
+		global.Log.Error("记录ES同步失败原因失败", zap.Uint("id", outbox.ID), zap.Error(err))
+		return false, fmt.Errorf("记录ES同步失败原因失败: %v", err)
+	}
+	return false, nil
+}
+
+// checkAdmin 校验用户是否为管理员
+func (s *EsOutboxSvc) checkAdmin(ctx context.Context, userID uint) error {
+	isAdmin, err := s.permissionService.IsAdmin(ctx, userID)
+	if err != nil {
+		global.Log.Error("获取用户角色失败", zap.Uint("userID", userID), zap.Error(err))
+		return fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	if !isAdmin {
+		return ErrEsOutboxForbidden
+	}
+	return nil
+}
+
+// applyEsOutbox 将一条操作应用到 ES，写入与更新都是幂等的，重复执行不影响结果
+func applyEsOutbox(ctx context.Context, outbox *entity.EsOutbox) error {
+	switch outbox.Op {
+	case consts.EsOutboxIndex:
+		return esUtil.Index(ctx, outbox.ArticleID, json.RawMessage(outbox.Payload))
+	case consts.EsOutboxUpdate:
+		return esUtil.Update(ctx, outbox.ArticleID, json.RawMessage(outbox.Payload))
+	case consts.EsOutboxDelete:
+		return esUtil.Delete(ctx, []string{outbox.ArticleID})
+	default:
+		return fmt.Errorf("未知的ES同步操作: %d", outbox.Op)
+	}
+}
+
+// outboxMaxAttempts 解析配置中的最多尝试次数，未配置时使用默认值
+func outboxMaxAttempts() int {
+	if global.Config.ES.OutboxAttempts > 0 {
+		return global.Config.ES.OutboxAttempts
+	}
+	return defaultOutboxAttempts
+}
+
+// outboxRetryDelay 第 attempts 次失败后的重试间隔：10s、20s、40s……最长 10 分钟
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBase
+	for i := 1; i < attempts && delay < outboxRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > outboxRetryMax {
+		delay = outboxRetryMax
+	}
+	return delay
+}
@@ -0,0 +1,333 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"personal_blog/global"
+	"personal_blog/internal/model/consts"
+	"personal_blog/internal/model/dto/request"
+	resp "personal_blog/internal/model/dto/response"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/pkg/articleUtils"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"personal_blog/pkg/util"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/scriptsorttype"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/go-redis/redis"
+	"go.uber.org/zap"
+)
+
+const (
+	// feedCachePrefix 订阅源缓存键前缀：feed:cache:<版本号>:<格式>:<分类|标签>:<名称>
+	feedCachePrefix = "feed:cache:"
+	// defaultFeedSize 未配置时订阅源包含的文章数
+	defaultFeedSize = 20
+	// maxFeedSize 订阅源包含的最大文章数
+	maxFeedSize = 100
+	// defaultFeedCacheTTL 未配置时订阅源的缓存时长
+	defaultFeedCacheTTL = time.Hour
+)
+
+// FeedSvc RSS/Atom 订阅源服务
+// - 订阅源由最新的公开文章生成，结果缓存在 Redis 中，文章写入 ES 后缓存立即失效（见 invalidatePublicCache）
+type FeedSvc struct{}
+
+func NewFeedSvc() *FeedSvc {
+	return &FeedSvc{}
+}
+
+// Feed 生成订阅源，返回 XML 内容与 ETag
+// - 分类、标签都为空时为全站订阅源，否则只包含该分类/标签下的文章
+func (f *FeedSvc) Feed(
+	ctx context.Context,
+	format consts.FeedFormat,
+	req request.FeedReq,
+) (body []byte, etag string, err error) {
+	category, tag := strings.TrimSpace(req.Category), strings.TrimSpace(req.Tag)
+	// 1、读取缓存，缓存不可用时直接生成
+	key, kerr := feedCacheKey(format, category, tag)
+	if kerr == nil {
+		cached, cerr := global.Redis.Get(key).Bytes()
+		if cerr == nil {
+			return cached, contentETag(cached), nil
+		}
+		if !errors.Is(cerr, redis.Nil) {
+			global.Log.Warn("读取订阅源缓存失败", zap.String("key", key), zap.Error(cerr))
+		}
+	} else {
+		global.Log.Warn("读取订阅源缓存版本失败", zap.Error(kerr))
+	}
+	// 2、查询最新的公开文章
+	articles, err := latestFeedArticles(ctx, category, tag)
+	if err != nil {
+		return nil, "", err
+	}
+	// 3、生成 XML
+	switch format {
+	case consts.FeedAtom:
+		body, err = buildAtom(articles, category, tag)
+	default:
+		body, err = buildRSS(articles, category, tag)
+	}
+	if err != nil {
+		global.Log.Error("生成订阅源失败", zap.String("format", format.String()), zap.Error(err))
+		return nil, "", fmt.Errorf("生成订阅源失败: %v", err)
+	}
+	// 4、写入缓存，失败只记录日志
+	// 4.a 按分类/标签过滤却没有文章时不缓存：分类/标签来自查询参数，避免任意取值不断产生新的缓存键
+	cacheable := len(articles) > 0 || (category == "" && tag == "")
+	if kerr == nil && cacheable {
+		if err = global.Redis.Set(key, body, feedCacheTTL()).Err(); err != nil {
+			global.Log.Warn("写入订阅源缓存失败", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return body, contentETag(body), nil
+}
+
+// feedArticle 订阅源中的单篇文章
+type feedArticle struct {
+	ID string
+	esModel.Article
+}
+
+// feedSortScript 订阅源排序值：与条目的发布时间（feedPublished）一致，取 publish_at，没有时取 created_at
+// - 定时发布的文章按实际发布时间排序，不会因创建时间较早而落在最新 N 篇之外
+const feedSortScript = `
+if (doc.containsKey('publish_at') && doc['publish_at'].size() > 0) {
+	return doc['publish_at'].value.toInstant().toEpochMilli();
+}
+return doc['created_at'].value.toInstant().toEpochMilli();
+`
+
+// latestFeedArticles 查询最新的公开文章（按发布时间倒序），可按分类或标签过滤
+func latestFeedArticles(ctx context.Context, category, tag string) ([]feedArticle, error) {
+	// 1、过滤条件：游客可见 + 分类/标签
+	filter := []types.Query{esModel.VisibleQuery(guestViewerID)}
+	if category != "" {
+		filter = append(filter, types.Query{Term: map[string]types.TermQuery{"category": {Value: category}}})
+	}
+	if tag != "" {
+		filter = append(filter, types.Query{Term: map[string]types.TermQuery{"tags": {Value: tag}}})
+	}
+	source := feedSortScript
+	option := esModel.EsOption{
+		Index: esModel.ArticleIndex(),
+		Request: &search.Request{
+			Query: &types.Query{Bool: &types.BoolQuery{Filter: filter}},
+			Sort: []types.SortCombinations{
+				types.SortOptions{Script_: &types.ScriptSort{
+					Type:   &scriptsorttype.Number,
+					Order:  &sortorder.Desc,
+					Script: types.Script{Source: &source},
+				}},
+				types.SortOptions{SortOptions: map[string]types.FieldSort{"created_at": {Order: &sortorder.Desc}}},
+			},
+		},
+		IncludeContent: false,
+	}
+	option.Page = 1
+	option.PageSize = feedSize()
+	// 2、查询
+	hits, _, err := esUtil.EsPagination(ctx, option)
+	if err != nil {
+		global.Log.Error("查询订阅源文章失败", zap.String("category", category), zap.String("tag", tag), zap.Error(err))
+		return nil, fmt.Errorf("查询订阅源文章失败: %v", err)
+	}
+	// 3、结果映射
+	articles := make([]feedArticle, 0, len(hits))
+	for _, hit := range hits {
+		var a feedArticle
+		if err = json.Unmarshal(hit.Source_, &a.Article); err != nil {
+			global.Log.Warn("结果映射失败", zap.Error(err))
+			return nil, fmt.Errorf("结果映射失败: %v", err)
+		}
+		if hit.Id_ != nil {
+			a.ID = *hit.Id_
+		}
+		articles = append(articles, a)
+	}
+	return articles, nil
+}
+
+// buildRSS 生成 RSS 2.0 订阅源
+func buildRSS(articles []feedArticle, category, tag string) ([]byte, error) {
+	website := global.Config.Website
+	// 1、频道信息
+	channel := resp.RSSChannel{
+		Title:       feedTitle(category, tag),
+		Link:        articleUtils.BlogURL(""),
+		Description: website.Description,
+		Language:    "zh-CN",
+		AtomLink: resp.AtomLink{
+			Href: feedSelfURL(consts.FeedRSS, category, tag),
+			Rel:  "self",
+			Type: consts.FeedRSS.ContentType(),
+		},
+		Items: make([]resp.RSSItem, 0, len(articles)),
+	}
+	if website.Email != "" {
+		channel.ManagingEditor = fmt.Sprintf("%s (%s)", website.Email, website.Name)
+	}
+	if len(articles) > 0 {
+		channel.LastBuildDate = feedUpdated(articles).Format(time.RFC1123Z)
+	}
+	// 2、文章列表
+	for _, a := range articles {
+		link := articleUtils.ArticleURL(a.ID)
+		item := resp.RSSItem{
+			Title:       a.Title,
+			Link:        link,
+			GUID:        resp.RSSGUID{IsPermaLink: true, Value: link},
+			Description: a.Abstract,
+			Categories:  feedCategories(a.Article),
+			PubDate:     articleTime(feedPublished(a.Article)).Format(time.RFC1123Z),
+		}
+		if website.Email != "" {
+			item.Author = fmt.Sprintf("%s (%s)", website.Email, website.Name)
+		}
+		channel.Items = append(channel.Items, item)
+	}
+	// 3、序列化
+	return marshalXML(resp.RSS{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom", Channel: channel})
+}
+
+// buildAtom 生成 Atom 1.0 订阅源
+func buildAtom(articles []feedArticle, category, tag string) ([]byte, error) {
+	website := global.Config.Website
+	self := feedSelfURL(consts.FeedAtom, category, tag)
+	// 1、订阅源信息
+	feed := resp.AtomFeed{
+		Title:    feedTitle(category, tag),
+		Subtitle: website.Description,
+		ID:       self,
+		Updated:  time.Now().Format(time.RFC3339),
+		Links: []resp.AtomLink{
+			{Href: articleUtils.BlogURL(""), Rel: "alternate", Type: "text/html"},
+			{Href: self, Rel: "self", Type: consts.FeedAtom.ContentType()},
+		},
+		Entries: make([]resp.AtomEntry, 0, len(articles)),
+	}
+	if website.Name != "" {
+		feed.Author = &resp.AtomAuthor{Name: website.Name, Email: website.Email}
+	}
+	if len(articles) > 0 {
+		feed.Updated = feedUpdated(articles).Format(time.RFC3339)
+	}
+	// 2、文章列表
+	for _, a := range articles {
+		link := articleUtils.ArticleURL(a.ID)
+		entry := resp.AtomEntry{
+			Title:     a.Title,
+			ID:        link,
+			Link:      resp.AtomLink{Href: link, Rel: "alternate", Type: "text/html"},
+			Published: articleTime(feedPublished(a.Article)).Format(time.RFC3339),
+			Updated:   articleTime(a.UpdatedAt).Format(time.RFC3339),
+			Summary:   a.Abstract,
+		}
+		for _, term := range feedCategories(a.Article) {
+			entry.Categories = append(entry.Categories, resp.AtomCategory{Term: term})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	// 3、序列化
+	return marshalXML(feed)
+}
+
+// feedTitle 订阅源标题：站点标题，分类/标签订阅源附加名称
+func feedTitle(category, tag string) string {
+	title := global.Config.Website.Title
+	switch {
+	case category != "":
+		return title + " - " + category
+	case tag != "":
+		return title + " - #" + tag
+	default:
+		return title
+	}
+}
+
+// feedSelfURL 订阅源自身地址（与路由保持一致）
+func feedSelfURL(format consts.FeedFormat, category, tag string) string {
+	name := format.String() + ".xml"
+	switch {
+	case category != "":
+		return articleUtils.BlogURL("feed/category/" + url.PathEscape(category) + "/" + name)
+	case tag != "":
+		return articleUtils.BlogURL("feed/tag/" + url.PathEscape(tag) + "/" + name)
+	default:
+		return articleUtils.BlogURL("feed/" + name)
+	}
+}
+
+// feedCategories 文章的分类与标签，作为订阅源条目的分类
+func feedCategories(a esModel.Article) []string {
+	list := make([]string, 0, len(a.Tags)+1)
+	if a.Category != "" {
+		list = append(list, a.Category)
+	}
+	return append(list, a.Tags...)
+}
+
+// feedPublished 文章发布时间：有发布时间时使用发布时间，否则使用创建时间
+func feedPublished(a esModel.Article) string {
+	if a.PublishAt != "" {
+		return a.PublishAt
+	}
+	return a.CreatedAt
+}
+
+// feedUpdated 订阅源的更新时间：取全部文章中最晚的更新时间，修改较早的文章同样会更新订阅源
+func feedUpdated(articles []feedArticle) time.Time {
+	var latest time.Time
+	for _, a := range articles {
+		if t := articleTime(a.UpdatedAt); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// feedCacheKey 订阅源缓存键，包含当前缓存版本号
+func feedCacheKey(format consts.FeedFormat, category, tag string) (string, error) {
+	version, err := publicCacheVersion()
+	if err != nil {
+		return "", err
+	}
+	scope := "all"
+	switch {
+	case category != "":
+		scope = "category:" + category
+	case tag != "":
+		scope = "tag:" + tag
+	}
+	return feedCachePrefix + version + ":" + format.String() + ":" + scope, nil
+}
+
+// feedSize 解析配置中的订阅源文章数，未配置时使用默认值
+func feedSize() int {
+	size := global.Config.Article.FeedSize
+	if size < 1 {
+		return defaultFeedSize
+	}
+	if size > maxFeedSize {
+		return maxFeedSize
+	}
+	return size
+}
+
+// feedCacheTTL 解析配置中的订阅源缓存时长，未配置或格式错误时使用默认值
+func feedCacheTTL() time.Duration {
+	ttl, err := util.ParseDuration(global.Config.Article.FeedCacheTTL)
+	if err != nil || ttl <= 0 {
+		return defaultFeedCacheTTL
+	}
+	return ttl
+}
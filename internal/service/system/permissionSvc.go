@@ -342,6 +342,24 @@ func (p *PermissionService) GetUserRoles(ctx context.Context, userID uint) ([]en
 	return result, nil
 }
 
+// IsAdmin 判断用户是否拥有管理员或超级管理员角色
+func (p *PermissionService) IsAdmin(ctx context.Context, userID uint) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+	roles, err := p.GetUserRoles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		switch role.Code {
+		case "admin", "super_admin", "SuperAdmin":
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GetUserMenus 获取用户可访问的菜单列表
 func (p *PermissionService) GetUserMenus(ctx context.Context, userID uint) ([]entity.Menu, error) {
 	menuRepo := p.repositoryGroup.SystemRepositorySupplier.GetMenuRepository()
@@ -0,0 +1,57 @@
+package system
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"personal_blog/global"
+	"time"
+
+	"github.com/go-redis/redis"
+	"go.uber.org/zap"
+)
+
+// publicCacheVersionKey 公开内容（订阅源、站点地图）缓存的版本号
+// - 缓存键中带有版本号，文章写入 ES 后自增，旧版本的缓存随之失效并按过期时间清理
+const publicCacheVersionKey = "public:cache:version"
+
+// publicCacheVersion 当前缓存版本号，尚未写入过文章时为 0
+func publicCacheVersion() (string, error) {
+	version, err := global.Redis.Get(publicCacheVersionKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return "0", nil
+	}
+	return version, err
+}
+
+// invalidatePublicCache 文章写入 ES 后使公开内容缓存失效，失败只记录日志（缓存最迟在过期后刷新）
+func invalidatePublicCache() {
+	if err := global.Redis.Incr(publicCacheVersionKey).Err(); err != nil {
+		global.Log.Warn("公开内容缓存失效失败", zap.Error(err))
+	}
+}
+
+// contentETag 根据内容生成 ETag
+func contentETag(body []byte) string {
+	sum := sha1.Sum(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// marshalXML 序列化为带 XML 声明的文档
+func marshalXML(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// articleTime 解析文档中的时间（yyyy-MM-dd HH:mm:ss，本地时区），解析失败时返回当前时间
+func articleTime(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+	if err != nil {
+		return time.Now()
+	}
+	return t
+}
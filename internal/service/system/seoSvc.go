@@ -0,0 +1,178 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"personal_blog/global"
+	resp "personal_blog/internal/model/dto/response"
+	esModel "personal_blog/internal/model/elasticsearch"
+	"personal_blog/pkg/articleUtils"
+	esUtil "personal_blog/pkg/elasticSearch"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/go-redis/redis"
+	"go.uber.org/zap"
+)
+
+// ErrSitemapNotFound 请求的站点地图分页不存在
+var ErrSitemapNotFound = errors.New("站点地图不存在")
+
+const (
+	// sitemapMaxURLs 单个站点地图文件最多包含的地址数（sitemaps.org 协议上限）
+	sitemapMaxURLs = 50000
+	// sitemapCachePrefix 站点地图缓存键前缀：sitemap:cache:<版本号>:<页码>，页码 0 表示 /sitemap.xml
+	sitemapCachePrefix = "sitemap:cache:"
+	// sitemapCacheTTL 站点地图缓存时长，文章写入时缓存会立即失效
+	sitemapCacheTTL = 6 * time.Hour
+)
+
+// SeoSvc 搜索引擎优化服务：站点地图与 robots.txt
+// - 站点地图包含首页、公开文章、分类页与标签页，地址均基于 website.blog_url
+type SeoSvc struct{}
+
+func NewSeoSvc() *SeoSvc {
+	return &SeoSvc{}
+}
+
+// Sitemap 生成站点地图，返回 XML 内容与 ETag
+// - page 为 0 时对应 /sitemap.xml：地址数不超过上限时直接返回全部地址，否则返回指向各分页的站点地图索引
+// - page 从 1 开始对应各分页，只有需要分页时才存在
+func (s *SeoSvc) Sitemap(ctx context.Context, page int) (body []byte, etag string, err error) {
+	// 1、读取缓存，缓存不可用时直接生成
+	version, verr := publicCacheVersion()
+	key := sitemapCachePrefix + version + ":" + strconv.Itoa(page)
+	if verr == nil {
+		cached, cerr := global.Redis.Get(key).Bytes()
+		if cerr == nil {
+			return cached, contentETag(cached), nil
+		}
+		if !errors.Is(cerr, redis.Nil) {
+			global.Log.Warn("读取站点地图缓存失败", zap.String("key", key), zap.Error(cerr))
+		}
+	} else {
+		global.Log.Warn("读取站点地图缓存版本失败", zap.Error(verr))
+	}
+	// 2、收集全部地址
+	urls, lastMod, err := sitemapURLs(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	pages := (len(urls) + sitemapMaxURLs - 1) / sitemapMaxURLs
+	// 3、生成 XML
+	switch {
+	case page == 0 && pages <= 1:
+		body, err = marshalXML(resp.SitemapURLSet{URLs: urls})
+	case page == 0:
+		index := resp.SitemapIndex{Sitemaps: make([]resp.SitemapURL, 0, pages)}
+		for i := 1; i <= pages; i++ {
+			index.Sitemaps = append(index.Sitemaps, resp.SitemapURL{
+				Loc:     articleUtils.BlogURL(fmt.Sprintf("sitemap/%d.xml", i)),
+				LastMod: lastMod,
+			})
+		}
+		body, err = marshalXML(index)
+	case page <= pages && pages > 1:
+		end := min(page*sitemapMaxURLs, len(urls))
+		body, err = marshalXML(resp.SitemapURLSet{URLs: urls[(page-1)*sitemapMaxURLs : end]})
+	default:
+		return nil, "", ErrSitemapNotFound
+	}
+	if err != nil {
+		global.Log.Error("生成站点地图失败", zap.Int("page", page), zap.Error(err))
+		return nil, "", fmt.Errorf("生成站点地图失败: %v", err)
+	}
+	// 4、写入缓存，失败只记录日志
+	if verr == nil {
+		if err = global.Redis.Set(key, body, sitemapCacheTTL).Err(); err != nil {
+			global.Log.Warn("写入站点地图缓存失败", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return body, contentETag(body), nil
+}
+
+// Robots 生成 robots.txt：按配置输出允许/禁止抓取的路径，并声明站点地图地址
+func (s *SeoSvc) Robots() string {
+	seo := global.Config.SEO
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	for _, path := range seo.RobotsAllow {
+		if path = strings.TrimSpace(path); path != "" {
+			b.WriteString("Allow: " + path + "\n")
+		}
+	}
+	for _, path := range seo.RobotsDisallow {
+		if path = strings.TrimSpace(path); path != "" {
+			b.WriteString("Disallow: " + path + "\n")
+		}
+	}
+	if extra := strings.TrimSpace(seo.RobotsExtra); extra != "" {
+		b.WriteString("\n" + extra + "\n")
+	}
+	b.WriteString("\nSitemap: " + articleUtils.BlogURL("sitemap.xml") + "\n")
+	return b.String()
+}
+
+// sitemapURLs 收集站点地图中的全部地址：首页、公开文章、分类页、标签页
+// - 同时返回最近一次文章更新时间，作为首页与站点地图索引的 lastmod
+func sitemapURLs(ctx context.Context) (urls []resp.SitemapURL, lastMod string, err error) {
+	// 1、公开文章，lastmod 取更新时间
+	query := esModel.VisibleQuery(guestViewerID)
+	var latest time.Time
+	var articles []resp.SitemapURL
+	err = esUtil.ScrollHits(ctx, esModel.ArticleIndex(), &query, types.SourceFilter{Includes: []string{"updated_at"}},
+		func(hits []types.Hit) error {
+			for _, hit := range hits {
+				if hit.Id_ == nil {
+					continue
+				}
+				var source struct {
+					UpdatedAt string `json:"updated_at"`
+				}
+				if err := json.Unmarshal(hit.Source_, &source); err != nil {
+					return err
+				}
+				updated := articleTime(source.UpdatedAt)
+				if updated.After(latest) {
+					latest = updated
+				}
+				articles = append(articles, resp.SitemapURL{
+					Loc:     articleUtils.ArticleURL(*hit.Id_),
+					LastMod: updated.Format(time.RFC3339),
+				})
+			}
+			return nil
+		})
+	if err != nil {
+		global.Log.Error("查询站点地图文章失败", zap.Error(err))
+		return nil, "", fmt.Errorf("查询站点地图文章失败: %v", err)
+	}
+	if !latest.IsZero() {
+		lastMod = latest.Format(time.RFC3339)
+	}
+	// 2、分类与标签（只统计公开文章，逐页读取全部词项，不受分桶数上限截断）
+	categories, err := esUtil.TermsCountAll(ctx, &query, "category")
+	if err != nil {
+		global.Log.Error("统计分类失败", zap.Error(err))
+		return nil, "", fmt.Errorf("统计分类失败: %v", err)
+	}
+	tags, err := esUtil.TermsCountAll(ctx, &query, "tags")
+	if err != nil {
+		global.Log.Error("统计标签失败", zap.Error(err))
+		return nil, "", fmt.Errorf("统计标签失败: %v", err)
+	}
+	// 3、按 首页 -> 分类 -> 标签 -> 文章 的顺序组装
+	urls = make([]resp.SitemapURL, 0, 1+len(categories)+len(tags)+len(articles))
+	urls = append(urls, resp.SitemapURL{Loc: articleUtils.BlogURL("/"), LastMod: lastMod})
+	for _, b := range categories {
+		urls = append(urls, resp.SitemapURL{Loc: articleUtils.CategoryURL(b.Key)})
+	}
+	for _, b := range tags {
+		urls = append(urls, resp.SitemapURL{Loc: articleUtils.TagURL(b.Key)})
+	}
+	return append(urls, articles...), lastMod, nil
+}
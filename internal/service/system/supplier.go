@@ -9,6 +9,16 @@ type Supplier interface {
 	GetUserSvc() *UserService
 	GetImageSvc() *ImageService
 	GetArticleSvc() *ArticleSvc
+	GetCommentSvc() *CommentSvc
+	GetArticleLikeSvc() *ArticleLikeSvc
+	GetArticleViewSvc() *ArticleViewSvc
+	GetArticleRevisionSvc() *ArticleRevisionSvc
+	GetArticleTaxonomySvc() *ArticleTaxonomySvc
+	GetEsOutboxSvc() *EsOutboxSvc
+	GetFeedSvc() *FeedSvc
+	GetSeoSvc() *SeoSvc
+	GetArticleExportSvc() *ArticleExportSvc
+	GetArticleSeriesSvc() *ArticleSeriesSvc
 }
 
 // SetUp 工厂函数，统一管理
@@ -23,7 +33,27 @@ func SetUp(repositoryGroup *repository.Group) Supplier {
 	ss.userService = NewUserService(repositoryGroup, ss.permissionService)
 	// 图片服务依赖仓储与存储驱动
 	ss.imageService = NewImageService(repositoryGroup)
-	// 文章服务
-	ss.articleSvc = NewArticleSvc(repositoryGroup)
+	// ES 同步发件箱服务依赖权限服务（死信管理仅管理员可操作）
+	ss.esOutboxSvc = NewEsOutboxSvc(repositoryGroup, ss.permissionService)
+	// 文章服务依赖权限服务（作者视角可见草稿）与发件箱服务（ES 写入）
+	ss.articleSvc = NewArticleSvc(repositoryGroup, ss.permissionService, ss.esOutboxSvc)
+	// 评论服务依赖权限服务（管理员可删除任意评论）
+	ss.commentSvc = NewCommentSvc(repositoryGroup, ss.permissionService)
+	// 文章收藏服务
+	ss.articleLikeSvc = NewArticleLikeSvc(repositoryGroup)
+	// 文章浏览量服务（基于 Redis，用不到repo层）
+	ss.articleViewSvc = NewArticleViewSvc()
+	// 文章历史版本服务依赖文章服务（恢复版本复用文章更新流程）
+	ss.articleRevisionSvc = NewArticleRevisionSvc(repositoryGroup, ss.articleSvc)
+	// 分类与标签管理服务依赖权限服务（重命名/合并仅管理员可操作）与发件箱服务（改写前清空发件箱）
+	ss.articleTaxonomySvc = NewArticleTaxonomySvc(repositoryGroup, ss.permissionService, ss.esOutboxSvc)
+	// 订阅源服务（基于 ES 与 Redis 缓存，用不到repo层）
+	ss.feedSvc = NewFeedSvc()
+	// 站点地图与 robots.txt 服务（用不到repo层）
+	ss.seoSvc = NewSeoSvc()
+	// 文章导出服务依赖文章服务（权限校验与作者判断）
+	ss.articleExportSvc = NewArticleExportSvc(repositoryGroup, ss.articleSvc)
+	// 文章专栏服务依赖文章服务（文章权限校验与可见性过滤）
+	ss.articleSeriesSvc = NewArticleSeriesSvc(repositoryGroup, ss.articleSvc)
 	return ss
 }
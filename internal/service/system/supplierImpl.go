@@ -2,12 +2,22 @@ package system
 
 // supplier implementation 用于底层实现
 type serviceSupplier struct {
-	jwtService        *JWTService
-	permissionService *PermissionService
-	baseService       *BaseService
-	userService       *UserService
-	imageService      *ImageService
-	articleSvc        *ArticleSvc
+	jwtService         *JWTService
+	permissionService  *PermissionService
+	baseService        *BaseService
+	userService        *UserService
+	imageService       *ImageService
+	articleSvc         *ArticleSvc
+	commentSvc         *CommentSvc
+	articleLikeSvc     *ArticleLikeSvc
+	articleViewSvc     *ArticleViewSvc
+	articleRevisionSvc *ArticleRevisionSvc
+	articleTaxonomySvc *ArticleTaxonomySvc
+	esOutboxSvc        *EsOutboxSvc
+	feedSvc            *FeedSvc
+	seoSvc             *SeoSvc
+	articleExportSvc   *ArticleExportSvc
+	articleSeriesSvc   *ArticleSeriesSvc
 }
 
 func (s *serviceSupplier) GetJWTSvc() *JWTService {
@@ -30,3 +40,43 @@ func (s *serviceSupplier) GetImageSvc() *ImageService {
 func (s *serviceSupplier) GetArticleSvc() *ArticleSvc {
 	return s.articleSvc
 }
+
+func (s *serviceSupplier) GetCommentSvc() *CommentSvc {
+	return s.commentSvc
+}
+
+func (s *serviceSupplier) GetArticleLikeSvc() *ArticleLikeSvc {
+	return s.articleLikeSvc
+}
+
+func (s *serviceSupplier) GetArticleViewSvc() *ArticleViewSvc {
+	return s.articleViewSvc
+}
+
+func (s *serviceSupplier) GetArticleRevisionSvc() *ArticleRevisionSvc {
+	return s.articleRevisionSvc
+}
+
+func (s *serviceSupplier) GetArticleTaxonomySvc() *ArticleTaxonomySvc {
+	return s.articleTaxonomySvc
+}
+
+func (s *serviceSupplier) GetEsOutboxSvc() *EsOutboxSvc {
+	return s.esOutboxSvc
+}
+
+func (s *serviceSupplier) GetFeedSvc() *FeedSvc {
+	return s.feedSvc
+}
+
+func (s *serviceSupplier) GetSeoSvc() *SeoSvc {
+	return s.seoSvc
+}
+
+func (s *serviceSupplier) GetArticleExportSvc() *ArticleExportSvc {
+	return s.articleExportSvc
+}
+
+func (s *serviceSupplier) GetArticleSeriesSvc() *ArticleSeriesSvc {
+	return s.articleSeriesSvc
+}
@@ -0,0 +1,31 @@
+package articleUtils
+
+import (
+	"net/url"
+	"personal_blog/global"
+	"strings"
+)
+
+// BlogURL 博客前台地址（配置 website.blog_url），path 非空时拼接在其后
+func BlogURL(path string) string {
+	base := strings.TrimRight(global.Config.Website.BlogUrl, "/")
+	if path == "" {
+		return base
+	}
+	return base + "/" + strings.TrimLeft(path, "/")
+}
+
+// ArticleURL 文章在博客前台的访问地址：<blog_url>/article/<文章ID>
+func ArticleURL(id string) string {
+	return BlogURL("article/" + url.PathEscape(id))
+}
+
+// CategoryURL 分类页在博客前台的访问地址：<blog_url>/category/<分类名>
+func CategoryURL(category string) string {
+	return BlogURL("category/" + url.PathEscape(category))
+}
+
+// TagURL 标签页在博客前台的访问地址：<blog_url>/tag/<标签名>
+func TagURL(tag string) string {
+	return BlogURL("tag/" + url.PathEscape(tag))
+}
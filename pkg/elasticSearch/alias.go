@@ -0,0 +1,139 @@
+package elasticSearch
+
+import (
+	"context"
+	"personal_blog/global"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/bulk"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/reindex"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/updatealiases"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/conflicts"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/versiontype"
+)
+
+// IndexCreateWithAlias 创建带版本号的物理索引，并将别名指向它（同时作为写入索引）
+func IndexCreateWithAlias(indexName, alias string, mapping *types.TypeMapping) error {
+	isWriteIndex := true
+	_, err := global.ESClient.Indices.Create(indexName).
+		Mappings(mapping).
+		Aliases(map[string]types.Alias{alias: {IsWriteIndex: &isWriteIndex}}).
+		Do(context.TODO())
+	return err
+}
+
+// ResolveIndices 解析名称对应的物理索引（按名称升序）
+// - name 为别名时返回别名指向的全部索引；为物理索引时返回其本身
+func ResolveIndices(ctx context.Context, name string) ([]string, error) {
+	res, err := global.ESClient.Indices.Get(name).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]string, 0, len(res))
+	for index := range res {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+	return indices, nil
+}
+
+// SwapAlias 原子地将别名切换到新索引
+// - detach 中的索引仅解除别名，数据保留，便于回滚
+// - drop 中的索引直接删除（用于与别名同名的旧物理索引，只有删除后别名才能生效）
+func SwapAlias(
+	ctx context.Context,
+	alias string,
+	newIndex string,
+	detach []string,
+	drop []string,
+) error {
+	// 1、所有动作放在同一个请求中，ES 保证原子执行，读写不会落到空别名上
+	actions := make([]types.IndicesAction, 0, len(detach)+len(drop)+1)
+	for i := range detach {
+		actions = append(actions, types.IndicesAction{Remove: &types.RemoveAction{Index: &detach[i], Alias: &alias}})
+	}
+	for i := range drop {
+		actions = append(actions, types.IndicesAction{RemoveIndex: &types.RemoveIndexAction{Index: &drop[i]}})
+	}
+	isWriteIndex := true
+	actions = append(actions, types.IndicesAction{Add: &types.AddAction{
+		Index:        &newIndex,
+		Alias:        &alias,
+		IsWriteIndex: &isWriteIndex,
+	}})
+	// 2、执行切换
+	_, err := global.ESClient.Indices.UpdateAliases().
+		Request(&updatealiases.Request{Actions: actions}).
+		Do(ctx)
+	return err
+}
+
+// SetWriteBlock 设置或解除索引的写入阻塞，阻塞期间对这些索引的写入请求直接失败
+// - 设置阻塞后立即刷新索引，保证阻塞前的写入都能被随后的搜索与复制读到
+func SetWriteBlock(ctx context.Context, indices []string, blocked bool) error {
+	index := strings.Join(indices, ",")
+	_, err := global.ESClient.Indices.PutSettings().
+		Indices(index).
+		Blocks(&types.IndexSettingBlocks{Write: blocked}).
+		Do(ctx)
+	if err != nil || !blocked {
+		return err
+	}
+	_, err = global.ESClient.Indices.Refresh().Index(index).Do(ctx)
+	return err
+}
+
+// Reindex 将源索引中的文档复制到目标索引，返回新建与更新的文档数
+// - 使用外部版本号：目标索引中已有的文档只有在源文档版本更高时才会被覆盖，重复执行可用于增量追平
+// - 版本冲突的文档跳过，不中断复制
+func Reindex(
+	ctx context.Context,
+	sources []string,
+	dest string,
+) (created int64, updated int64, err error) {
+	external := versiontype.External
+	proceed := conflicts.Proceed
+	res, err := global.ESClient.Reindex().
+		Request(&reindex.Request{
+			Source:    types.ReindexSource{Index: sources},
+			Dest:      types.ReindexDestination{Index: dest, VersionType: &external},
+			Conflicts: &proceed,
+		}).
+		Refresh(true).
+		WaitForCompletion(true).
+		Do(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if res.Created != nil {
+		created = *res.Created
+	}
+	if res.Updated != nil {
+		updated = *res.Updated
+	}
+	return created, updated, nil
+}
+
+// DeleteFromIndex 用于从指定的物理索引中批量删除文档
+func DeleteFromIndex(
+	ctx context.Context,
+	index string,
+	ids []string,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var request bulk.Request
+	for i := range ids {
+		request = append(request, types.OperationContainer{Delete: &types.DeleteOperation{Id_: &ids[i]}})
+	}
+	_, err := global.ESClient.Bulk().
+		Request(&request).
+		Index(index).
+		Refresh(refresh.True).
+		Do(ctx)
+	return err
+}
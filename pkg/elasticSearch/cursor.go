@@ -0,0 +1,194 @@
+package elasticSearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"personal_blog/global"
+	elasticsearch "personal_blog/internal/model/elasticsearch"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"go.uber.org/zap"
+)
+
+// pitKeepAlive 游标分页中时间点（PIT）的保留时长，超过该时长未翻页游标即失效
+const pitKeepAlive = "5m"
+
+// ErrCursorInvalid 游标无法解析或已过期
+var ErrCursorInvalid = errors.New("游标无效或已过期，请从第一页重新加载")
+
+// cursorToken 游标内容，编码后作为不透明字符串返回给调用方
+// - 第一页不打开时间点，游标只记录下一页的起始位置（Offset）；翻到第二页时才打开时间点，此后按 search_after 翻页
+type cursorToken struct {
+	Hash        string             `json:"h"`           // 查询条件与排序的指纹，防止游标用于其他查询
+	Offset      int                `json:"o,omitempty"` // 尚未打开时间点时，下一页的起始位置
+	PitID       string             `json:"p,omitempty"` // 时间点ID
+	SearchAfter []types.FieldValue `json:"s,omitempty"` // 上一页最后一条文档的排序值
+}
+
+// EsCursorPagination 基于时间点（PIT）与 search_after 的游标分页，不受 index.max_result_window 限制，适合无限滚动
+// - cursor 为空时直接查询第一页，不打开时间点；只有调用方继续翻页时才打开时间点，此后翻页期间看到的是同一份数据快照
+// - 返回的 next 为下一页的游标，没有更多数据时为空并关闭时间点
+// - 游标记录了查询条件与排序的指纹，查询条件或排序与生成游标时不同时返回 ErrCursorInvalid；未指定排序时按相关性排序
+func EsCursorPagination(
+	ctx context.Context,
+	option elasticsearch.EsOption,
+	cursor string,
+) (list []types.Hit, total int64, next string, err error) {
+	// 1、设置查询
+	if option.PageSize < 1 {
+		option.PageSize = 10 // 每页记录数不能小于1，默认为10
+	}
+	// 1.a 多取一条用于判断是否还有下一页
+	size := option.PageSize + 1
+	option.Request.Size = &size
+	option.Request.From = nil
+	// 1.b search_after 依赖确定的排序，时间点会自动追加 _shard_doc 作为最终排序保证唯一
+	if len(option.Request.Sort) == 0 {
+		option.Request.Sort = []types.SortCombinations{types.SortOptions{Score_: &types.ScoreSort{Order: &sortorder.Desc}}}
+	}
+	if len(option.SourceIncludes) == 0 {
+		option.SourceIncludes = defaultSourceIncludes(option.IncludeContent)
+	}
+
+	// 2、解析游标并校验指纹
+	hash, err := cursorFingerprint(option.Request)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	token := cursorToken{Hash: hash}
+	if cursor != "" {
+		if token, err = decodeCursor(cursor, hash); err != nil {
+			return nil, 0, "", err
+		}
+	}
+
+	// 3、确定翻页方式：第一页直接查询索引；第二页打开时间点并按起始位置查询；之后按 search_after 查询
+	switch {
+	case cursor == "":
+	case token.PitID == "":
+		pit, err := global.ESClient.OpenPointInTime(option.Index).KeepAlive(pitKeepAlive).Do(ctx)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		token.PitID = pit.Id
+		option.Request.From = &token.Offset
+	default:
+		option.Request.SearchAfter = token.SearchAfter
+	}
+	call := global.ESClient.Search()
+	if token.PitID != "" {
+		// 通过时间点查询时不能指定索引
+		option.Request.Pit = &types.PointInTimeReference{Id: token.PitID, KeepAlive: pitKeepAlive}
+	} else {
+		call = call.Index(option.Index)
+	}
+
+	// 4、执行查询，时间点过期或不存在时视为游标失效
+	res, err := call.
+		Request(option.Request).
+		SourceIncludes_(option.SourceIncludes...).
+		Do(ctx)
+	if err != nil {
+		var esErr *types.ElasticsearchError
+		if token.PitID != "" && errors.As(err, &esErr) && esErr.Status == http.StatusNotFound {
+			return nil, 0, "", ErrCursorInvalid
+		}
+		return nil, 0, "", err
+	}
+	// 4.a 每次查询都可能返回新的时间点ID，后续请求应使用最新的
+	if res.PitId != nil {
+		token.PitID = *res.PitId
+	}
+	list = res.Hits.Hits
+	if res.Hits.Total != nil {
+		total = res.Hits.Total.Value
+	}
+
+	// 5、生成下一页游标；已到最后一页时关闭时间点
+	if len(list) <= option.PageSize {
+		if token.PitID != "" {
+			closePointInTime(token.PitID)
+		}
+		return list, total, "", nil
+	}
+	list = list[:option.PageSize]
+	if token.PitID == "" {
+		token.Offset = option.PageSize
+	} else {
+		token.Offset, token.SearchAfter = 0, list[len(list)-1].Sort
+	}
+	if next, err = encodeCursor(token); err != nil {
+		return nil, 0, "", err
+	}
+	return list, total, next, nil
+}
+
+// cursorFingerprint 查询条件与排序的指纹
+// - 排序脚本的参数（如置顶排序中的当前时间）每次请求都会变化，只比较脚本内容
+func cursorFingerprint(req *search.Request) (string, error) {
+	sorts := make([]types.SortCombinations, 0, len(req.Sort))
+	for _, sort := range req.Sort {
+		if opt, ok := sort.(types.SortOptions); ok && opt.Script_ != nil {
+			script := *opt.Script_
+			script.Script.Params = nil
+			opt.Script_ = &script
+			sort = opt
+		}
+		sorts = append(sorts, sort)
+	}
+	data, err := json.Marshal(struct {
+		Query *types.Query             `json:"query"`
+		Sort  []types.SortCombinations `json:"sort"`
+	}{req.Query, sorts})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+// encodeCursor 将游标内容编码为 URL 安全的字符串
+func encodeCursor(token cursorToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor 解析游标并校验指纹，排序值按 json.Number 读取，原样传回 ES 而不再转换为浮点数
+func decodeCursor(cursor string, hash string) (cursorToken, error) {
+	var token cursorToken
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return token, ErrCursorInvalid
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&token); err != nil || token.Hash != hash {
+		return token, ErrCursorInvalid
+	}
+	// 未打开时间点时只有起始位置，打开后只有时间点与排序值
+	if token.PitID == "" {
+		if token.Offset < 1 || len(token.SearchAfter) > 0 {
+			return token, ErrCursorInvalid
+		}
+	} else if token.Offset != 0 || len(token.SearchAfter) == 0 {
+		return token, ErrCursorInvalid
+	}
+	return token, nil
+}
+
+// closePointInTime 关闭时间点释放资源，失败时等待其自然过期
+func closePointInTime(pitID string) {
+	if _, err := global.ESClient.ClosePointInTime().Id(pitID).Do(context.TODO()); err != nil {
+		global.Log.Warn("关闭时间点失败", zap.Error(err))
+	}
+}
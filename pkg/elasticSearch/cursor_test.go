@@ -0,0 +1,138 @@
+package elasticSearch
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name    string
+		cursor  string
+		hash    string
+		want    cursorToken
+		wantErr bool
+	}{
+		{
+			name:   "第一页之后的起始位置",
+			cursor: raw(`{"h":"abc","o":10}`),
+			hash:   "abc",
+			want:   cursorToken{Hash: "abc", Offset: 10},
+		},
+		{
+			name:   "时间点与排序值，数值按 json.Number 保留",
+			cursor: raw(`{"h":"abc","p":"pit-1","s":[1700000000000,"go",12345678901234567]}`),
+			hash:   "abc",
+			want: cursorToken{Hash: "abc", PitID: "pit-1", SearchAfter: []types.FieldValue{
+				json.Number("1700000000000"), "go", json.Number("12345678901234567"),
+			}},
+		},
+		{name: "不是 base64", cursor: "not base64!", hash: "abc", wantErr: true},
+		{name: "不是 JSON", cursor: raw(`pit-1`), hash: "abc", wantErr: true},
+		{name: "指纹不一致", cursor: raw(`{"h":"other","o":10}`), hash: "abc", wantErr: true},
+		{name: "缺少指纹", cursor: raw(`{"o":10}`), hash: "abc", wantErr: true},
+		{name: "没有起始位置也没有时间点", cursor: raw(`{"h":"abc"}`), hash: "abc", wantErr: true},
+		{name: "没有时间点却有排序值", cursor: raw(`{"h":"abc","o":10,"s":[1]}`), hash: "abc", wantErr: true},
+		{name: "时间点缺少排序值", cursor: raw(`{"h":"abc","p":"pit-1"}`), hash: "abc", wantErr: true},
+		{name: "时间点同时带起始位置", cursor: raw(`{"h":"abc","o":10,"p":"pit-1","s":[1]}`), hash: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.cursor, tt.hash)
+			if tt.wantErr {
+				if !errors.Is(err, ErrCursorInvalid) {
+					t.Fatalf("decodeCursor() error = %v, 期望 ErrCursorInvalid", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCursor() = %#v, 期望 %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		token cursorToken
+	}{
+		{name: "起始位置", token: cursorToken{Hash: "abc", Offset: 20}},
+		{name: "时间点", token: cursorToken{Hash: "abc", PitID: "pit-1", SearchAfter: []types.FieldValue{
+			json.Number("-2147483649"), json.Number("1700000000000"), json.Number("42"),
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := encodeCursor(tt.token)
+			if err != nil {
+				t.Fatalf("encodeCursor() error = %v", err)
+			}
+			got, err := decodeCursor(cursor, tt.token.Hash)
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.token) {
+				t.Errorf("decodeCursor() = %#v, 期望 %#v", got, tt.token)
+			}
+		})
+	}
+}
+
+func TestCursorFingerprint(t *testing.T) {
+	term := func(tag string) *types.Query {
+		return &types.Query{Term: map[string]types.TermQuery{"tags": {Value: tag}}}
+	}
+	scriptSort := func(now string) types.SortCombinations {
+		source := "return params.now;"
+		return types.SortOptions{Script_: &types.ScriptSort{
+			Order:  &sortorder.Desc,
+			Script: types.Script{Source: &source, Params: map[string]json.RawMessage{"now": json.RawMessage(now)}},
+		}}
+	}
+	createdAt := func(order sortorder.SortOrder) types.SortCombinations {
+		return types.SortOptions{SortOptions: map[string]types.FieldSort{"created_at": {Order: &order}}}
+	}
+	base := &search.Request{Query: term("go"), Sort: []types.SortCombinations{scriptSort("1"), createdAt(sortorder.Desc)}}
+	tests := []struct {
+		name string
+		req  *search.Request
+		same bool
+	}{
+		{name: "相同的查询与排序", req: &search.Request{Query: term("go"), Sort: []types.SortCombinations{scriptSort("1"), createdAt(sortorder.Desc)}}, same: true},
+		{name: "只有脚本参数不同", req: &search.Request{Query: term("go"), Sort: []types.SortCombinations{scriptSort("2"), createdAt(sortorder.Desc)}}, same: true},
+		{name: "查询条件不同", req: &search.Request{Query: term("java"), Sort: []types.SortCombinations{scriptSort("1"), createdAt(sortorder.Desc)}}},
+		{name: "排序不同", req: &search.Request{Query: term("go"), Sort: []types.SortCombinations{scriptSort("1"), createdAt(sortorder.Asc)}}},
+		{name: "缺少排序", req: &search.Request{Query: term("go")}},
+	}
+	want, err := cursorFingerprint(base)
+	if err != nil {
+		t.Fatalf("cursorFingerprint() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cursorFingerprint(tt.req)
+			if err != nil {
+				t.Fatalf("cursorFingerprint() error = %v", err)
+			}
+			if (got == want) != tt.same {
+				t.Errorf("cursorFingerprint() = %q, 基准 %q, 期望相同 = %v", got, want, tt.same)
+			}
+		})
+	}
+	// 计算指纹不能修改原请求中的脚本参数
+	if params := base.Sort[0].(types.SortOptions).Script_.Script.Params; params == nil {
+		t.Errorf("cursorFingerprint() 清空了原请求的脚本参数")
+	}
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/bulk"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"net/http"
 	"personal_blog/global"
 	elasticsearch "personal_blog/internal/model/elasticsearch"
 
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/update"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/calendarinterval"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/conflicts"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/refresh"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"go.uber.org/zap"
 )
 
 /*
@@ -42,6 +49,34 @@ func IndexExists(indexName string) (bool, error) {
 	return global.ESClient.Indices.Exists(indexName).Do(context.TODO())
 }
 
+// DefaultAnalyzer ES 内置的标准分词器，所配置的分词器不可用时回退使用
+const DefaultAnalyzer = "standard"
+
+// ResolveAnalyzer 检查分词器在集群中是否可用，确认不存在（如未安装 IK/smartcn 插件）时回退为 standard
+// - name 为空时返回空字符串，表示使用 ES 默认分词器
+// - 网络错误、集群异常等无法确认分词器是否存在的情况返回错误，避免误用 standard 永久建出索引
+func ResolveAnalyzer(ctx context.Context, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	// 用一段中文试分词，分词器不存在时 ES 返回 400 illegal_argument_exception
+	_, err := global.ESClient.Indices.Analyze().
+		Analyzer(name).
+		Text("中文分词测试").
+		Do(ctx)
+	if err == nil {
+		return name, nil
+	}
+	var esErr *types.ElasticsearchError
+	if errors.As(err, &esErr) && esErr.Status == http.StatusBadRequest &&
+		esErr.ErrorCause.Type == "illegal_argument_exception" {
+		global.Log.Warn("分词器不存在，回退为 standard",
+			zap.String("analyzer", name), zap.Error(err))
+		return DefaultAnalyzer, nil
+	}
+	return "", fmt.Errorf("检查分词器 %s 失败: %w", name, err)
+}
+
 // Get 用于通过ID从 Elasticsearch 获取文章
 func Get(
 	ctx context.Context,
@@ -65,6 +100,21 @@ func Get(
 	return a, err
 }
 
+// Index 用于按指定ID写入文章，已存在时整体覆盖，重复执行结果一致
+func Index(
+	ctx context.Context,
+	articleID string,
+	v any,
+) error {
+	_, err := global.ESClient.
+		Index(elasticsearch.ArticleIndex()).
+		Id(articleID).
+		Document(v).
+		Refresh(refresh.True).
+		Do(ctx)
+	return err
+}
+
 // Update 用于更新文章数据
 func Update(
 	ctx context.Context,
@@ -86,6 +136,169 @@ func Update(
 	return err
 }
 
+// incrFieldScript 对数值字段做增减，字段缺失时按 0 处理，且结果不小于 0
+const incrFieldScript = `
+if (ctx._source[params.field] == null) { ctx._source[params.field] = 0; }
+ctx._source[params.field] += params.delta;
+if (ctx._source[params.field] < 0) { ctx._source[params.field] = 0; }`
+
+// IncrField 用于原子地增减文章的计数字段（如 comments、likes、views）
+// - 基于 painless 脚本在 ES 端完成读改写，避免并发覆盖
+func IncrField(
+	ctx context.Context,
+	articleID string,
+	field string,
+	delta int,
+) error {
+	// 1、构建脚本参数
+	script, err := incrScript(field, delta)
+	if err != nil {
+		return err
+	}
+	// 2、执行脚本更新，版本冲突时由 ES 自动重试
+	_, err = global.ESClient.
+		Update(elasticsearch.ArticleIndex(), articleID).
+		Request(&update.Request{Script: script}).
+		RetryOnConflict(3).
+		Refresh(refresh.True).
+		Do(ctx)
+	return err
+}
+
+// BulkIncrField 用于批量增减多篇文章的同一计数字段
+// - deltas 为 文章ID -> 增量，一次 bulk 请求完成全部更新
+// - 文档不存在（404）的条目直接丢弃，其余失败的条目原样返回，便于调用方回补
+func BulkIncrField(
+	ctx context.Context,
+	field string,
+	deltas map[string]int,
+) (failed map[string]int, err error) {
+	if len(deltas) == 0 {
+		return nil, nil
+	}
+	// 1、构建批量更新请求：每篇文章一条 update 操作 + 一条脚本动作
+	var request bulk.Request
+	retry := 3
+	for id, delta := range deltas {
+		script, err := incrScript(field, delta)
+		if err != nil {
+			return deltas, err
+		}
+		articleID := id
+		request = append(request,
+			types.OperationContainer{Update: &types.UpdateOperation{Id_: &articleID, RetryOnConflict: &retry}},
+			types.UpdateAction{Script: script},
+		)
+	}
+	// 2、执行批量请求，计数类字段无需立即可见，不强制刷新
+	res, err := global.ESClient.Bulk().
+		Request(&request).
+		Index(elasticsearch.ArticleIndex()).
+		Do(ctx)
+	if err != nil {
+		return deltas, err
+	}
+	// 3、收集失败条目
+	failed = make(map[string]int)
+	if res.Errors {
+		for _, item := range res.Items {
+			for _, result := range item {
+				if result.Error == nil || result.Id_ == nil || result.Status == 404 {
+					continue
+				}
+				failed[*result.Id_] = deltas[*result.Id_]
+			}
+		}
+	}
+	return failed, nil
+}
+
+// UpdateByQueryResult 批量更新结果
+type UpdateByQueryResult struct {
+	Updated          int64    // 更新成功的文档数
+	VersionConflicts int64    // 因版本冲突跳过的文档数
+	Failures         []string // 无法恢复的失败（文档ID: 原因），非空时更新已中途结束
+	TimedOut         bool     // 是否有请求超时
+}
+
+// Complete 是否所有命中的文档都已更新（没有版本冲突、失败与超时）
+func (r UpdateByQueryResult) Complete() bool {
+	return r.VersionConflicts == 0 && len(r.Failures) == 0 && !r.TimedOut
+}
+
+// UpdateByQuery 用于按查询条件批量更新文章
+// - 版本冲突的文档跳过并计入 VersionConflicts，是否重试由调用方决定
+func UpdateByQuery(
+	ctx context.Context,
+	query *types.Query,
+	script *types.Script,
+) (UpdateByQueryResult, error) {
+	res, err := global.ESClient.
+		UpdateByQuery(elasticsearch.ArticleIndex()).
+		Query(query).
+		Script(script).
+		Conflicts(conflicts.Proceed).
+		Refresh(true).
+		Do(ctx)
+	if err != nil {
+		return UpdateByQueryResult{}, err
+	}
+	var result UpdateByQueryResult
+	if res.Updated != nil {
+		result.Updated = *res.Updated
+	}
+	if res.VersionConflicts != nil {
+		result.VersionConflicts = *res.VersionConflicts
+	}
+	if res.TimedOut != nil {
+		result.TimedOut = *res.TimedOut
+	}
+	for _, f := range res.Failures {
+		reason := f.Cause.Type
+		if f.Cause.Reason != nil {
+			reason = *f.Cause.Reason
+		}
+		result.Failures = append(result.Failures, f.Id+": "+reason)
+	}
+	return result, nil
+}
+
+// Count 统计符合查询条件的文章数，query 为 nil 时统计全部文章
+func Count(
+	ctx context.Context,
+	query *types.Query,
+) (int64, error) {
+	req := global.ESClient.Count().Index(elasticsearch.ArticleIndex())
+	if query != nil {
+		req = req.Query(query)
+	}
+	res, err := req.Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.Count, nil
+}
+
+// incrScript 构建计数字段增减脚本
+func incrScript(field string, delta int) (*types.Script, error) {
+	fieldRaw, err := json.Marshal(field)
+	if err != nil {
+		return nil, err
+	}
+	deltaRaw, err := json.Marshal(delta)
+	if err != nil {
+		return nil, err
+	}
+	source := incrFieldScript
+	return &types.Script{
+		Source: &source,
+		Params: map[string]json.RawMessage{
+			"field": fieldRaw,
+			"delta": deltaRaw,
+		},
+	}, nil
+}
+
 // Delete 用于删除 Elasticsearch 中的文章
 func Delete(
 	ctx context.Context,
@@ -104,6 +317,59 @@ func Delete(
 	return err
 }
 
+// BulkDoc 批量写入的单篇文章，Source 为文档原始 JSON
+type BulkDoc struct {
+	ID     string
+	Source json.RawMessage
+}
+
+// BulkIndex 用于按指定ID批量写入文章，已存在时整体覆盖，重复执行结果一致
+// - 任一条目写入失败时返回错误，便于调用方从该批次重新开始
+func BulkIndex(
+	ctx context.Context,
+	docs []BulkDoc,
+) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	// 1、构建批量写入请求：每篇文章一条 index 操作 + 一条文档内容
+	var request bulk.Request
+	for i := range docs {
+		request = append(request,
+			types.OperationContainer{Index: &types.IndexOperation{Id_: &docs[i].ID}},
+			docs[i].Source,
+		)
+	}
+	// 2、执行批量请求
+	res, err := global.ESClient.Bulk().
+		Request(&request).
+		Index(elasticsearch.ArticleIndex()).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	// 3、汇总失败条目
+	if res.Errors {
+		for _, item := range res.Items {
+			for _, result := range item {
+				if result.Error != nil && result.Error.Reason != nil {
+					return fmt.Errorf("document %v: %s", stringValue(result.Id_), *result.Error.Reason)
+				}
+			}
+		}
+		return errors.New("bulk index failed")
+	}
+	return nil
+}
+
+// stringValue 取字符串指针的值，nil 时返回空字符串
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // EsPagination 实现 Elasticsearch 数据分页查询
 func EsPagination(
 	ctx context.Context,
@@ -127,23 +393,7 @@ func EsPagination(
 
 	// 3、设置返回字段（若调用方未指定，则按 IncludeContent 构造默认字段集）
 	if len(option.SourceIncludes) == 0 {
-		base := []string{
-			"created_at",
-			"updated_at",
-			"cover",
-			"title",
-			"keyword",
-			"category",
-			"tags",
-			"abstract",
-			"visible_range",
-			"views",
-			"comments",
-			"likes"}
-		if option.IncludeContent {
-			base = append(base, "content")
-		}
-		option.SourceIncludes = base
+		option.SourceIncludes = defaultSourceIncludes(option.IncludeContent)
 	}
 
 	// 4、执行 Elasticsearch 搜索查询
@@ -161,3 +411,289 @@ func EsPagination(
 	total = res.Hits.Total.Value // 获取符合条件的文档总数
 	return list, total, nil      // 返回查询结果和总文档数
 }
+
+// defaultSourceIncludes 文章列表默认返回的字段，includeContent 为 true 时包含 content
+func defaultSourceIncludes(includeContent bool) []string {
+	base := []string{
+		"created_at",
+		"updated_at",
+		"cover",
+		"title",
+		"keyword",
+		"category",
+		"tags",
+		"abstract",
+		"visible_range",
+		"visibleRange",
+		"author_id",
+		"author_uuid",
+		"status",
+		"publish_at",
+		"views",
+		"comments",
+		"likes",
+		"pinned",
+		"featured"}
+	if includeContent {
+		base = append(base, "content")
+	}
+	return base
+}
+
+// GetByIDs 按ID批量获取文章（不含 content）
+// - 返回结果的顺序与 ids 保持一致，不存在的文档会被跳过
+func GetByIDs(
+	ctx context.Context,
+	ids []string,
+) ([]types.Hit, error) {
+	if len(ids) == 0 {
+		return []types.Hit{}, nil
+	}
+	// 1、通过 ids 查询一次性取回
+	option := elasticsearch.EsOption{
+		Index:   elasticsearch.ArticleIndex(),
+		Request: &search.Request{Query: &types.Query{Ids: &types.IdsQuery{Values: ids}}},
+	}
+	option.Page = 1
+	option.PageSize = len(ids)
+	hits, _, err := EsPagination(ctx, option)
+	if err != nil {
+		return nil, err
+	}
+	// 2、按传入顺序重排
+	byID := make(map[string]types.Hit, len(hits))
+	for _, h := range hits {
+		if h.Id_ != nil {
+			byID[*h.Id_] = h
+		}
+	}
+	out := make([]types.Hit, 0, len(hits))
+	for _, id := range ids {
+		if h, ok := byID[id]; ok {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+// matchIDsBatchSize 按ID过滤时每批查询的ID数量，不超过 index.max_result_window
+const matchIDsBatchSize = 1000
+
+// MatchIDs 返回 ids 中存在且满足 query 的文章ID（分批查询，只取ID不取文档内容）
+func MatchIDs(
+	ctx context.Context,
+	ids []string,
+	query types.Query,
+) (map[string]struct{}, error) {
+	matched := make(map[string]struct{}, len(ids))
+	for start := 0; start < len(ids); start += matchIDsBatchSize {
+		batch := ids[start:min(start+matchIDsBatchSize, len(ids))]
+		size := len(batch)
+		res, err := global.ESClient.Search().
+			Index(elasticsearch.ArticleIndex()).
+			Request(&search.Request{
+				Query: &types.Query{Bool: &types.BoolQuery{Filter: []types.Query{
+					{Ids: &types.IdsQuery{Values: batch}},
+					query,
+				}}},
+				Size:    &size,
+				Source_: false,
+			}).
+			Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, hit := range res.Hits.Hits {
+			if hit.Id_ != nil {
+				matched[*hit.Id_] = struct{}{}
+			}
+		}
+	}
+	return matched, nil
+}
+
+// TermBucket 词项聚合的单个分桶
+type TermBucket struct {
+	Key   string // 词项（如分类名、标签名）
+	Count int64  // 命中的文档数
+}
+
+// TermsCount 按指定 keyword 字段做词项聚合，统计每个词项命中的文章数（按数量倒序）
+// - query 为 nil 时统计全部文章
+// - size 为返回的最大分桶数，超出的词项被截断，需要完整结果时使用 TermsCountAll
+func TermsCount(
+	ctx context.Context,
+	query *types.Query,
+	field string,
+	size int,
+) ([]TermBucket, error) {
+	// 1、构建聚合请求，只要聚合结果，不返回文档
+	const aggName = "terms_count"
+	req := &search.Request{
+		Query: query,
+		Aggregations: map[string]types.Aggregations{
+			aggName: {Terms: &types.TermsAggregation{Field: &field, Size: &size}},
+		},
+	}
+	res, err := global.ESClient.Search().
+		Index(elasticsearch.ArticleIndex()).
+		Request(req).
+		Size(0).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 2、解析分桶，聚合缺失或类型不符时返回错误，避免调用方把异常结果当作“没有数据”
+	agg, ok := res.Aggregations[aggName].(*types.StringTermsAggregate)
+	if !ok {
+		return nil, fmt.Errorf("terms aggregation on %s: unexpected result %T", field, res.Aggregations[aggName])
+	}
+	buckets, ok := agg.Buckets.([]types.StringTermsBucket)
+	if !ok {
+		return nil, fmt.Errorf("terms aggregation on %s: unexpected buckets %T", field, agg.Buckets)
+	}
+	list := make([]TermBucket, 0, len(buckets))
+	for _, b := range buckets {
+		key, _ := b.Key.(string)
+		list = append(list, TermBucket{Key: key, Count: b.DocCount})
+	}
+	return list, nil
+}
+
+// termsPageSize 组合聚合每页返回的分桶数
+const termsPageSize = 1000
+
+// TermsCountAll 按指定 keyword 字段统计全部词项命中的文章数（按词项升序），不受分桶数上限截断
+// - 通过组合聚合（composite）逐页读取，结果不完整或聚合缺失时返回错误
+// - query 为 nil 时统计全部文章
+func TermsCountAll(
+	ctx context.Context,
+	query *types.Query,
+	field string,
+) ([]TermBucket, error) {
+	const aggName = "terms_all"
+	size := termsPageSize
+	list := make([]TermBucket, 0)
+	var after types.CompositeAggregateKey
+	for {
+		// 1、按上一页的 after_key 继续读取
+		req := &search.Request{
+			Query: query,
+			Aggregations: map[string]types.Aggregations{
+				aggName: {Composite: &types.CompositeAggregation{
+					Size:    &size,
+					After:   after,
+					Sources: []map[string]types.CompositeAggregationSource{{"key": {Terms: &types.CompositeTermsAggregation{Field: &field}}}},
+				}},
+			},
+		}
+		res, err := global.ESClient.Search().
+			Index(elasticsearch.ArticleIndex()).
+			Request(req).
+			Size(0).
+			Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// 2、解析分桶
+		agg, ok := res.Aggregations[aggName].(*types.CompositeAggregate)
+		if !ok {
+			return nil, fmt.Errorf("composite aggregation on %s: unexpected result %T", field, res.Aggregations[aggName])
+		}
+		buckets, ok := agg.Buckets.([]types.CompositeBucket)
+		if !ok {
+			return nil, fmt.Errorf("composite aggregation on %s: unexpected buckets %T", field, agg.Buckets)
+		}
+		for _, b := range buckets {
+			key, ok := b.Key["key"].(string)
+			if !ok {
+				return nil, fmt.Errorf("composite aggregation on %s: unexpected key %v", field, b.Key["key"])
+			}
+			list = append(list, TermBucket{Key: key, Count: b.DocCount})
+		}
+		// 3、没有 after_key 或本页为空时读取完毕
+		if len(buckets) == 0 || len(agg.AfterKey) == 0 {
+			return list, nil
+		}
+		after = agg.AfterKey
+	}
+}
+
+// DateBucket 日期直方图的单个分桶
+type DateBucket struct {
+	Key   string      // 分桶起始时间（按 format 格式化）
+	Count int64       // 命中的文档数
+	Hits  []types.Hit // 分桶内排在最前的文档
+}
+
+// DateHistogram 按日期字段做日历间隔直方图聚合（按时间倒序，不返回空分桶）
+// - query 为 nil 时统计全部文章
+// - 每个分桶附带按 field 倒序的前 topHits 篇文档，只返回 sourceIncludes 指定的字段；topHits 为 0 时不取文档
+func DateHistogram(
+	ctx context.Context,
+	query *types.Query,
+	field string,
+	interval calendarinterval.CalendarInterval,
+	format string,
+	topHits int,
+	sourceIncludes []string,
+) ([]DateBucket, error) {
+	// 1、构建聚合请求，只要聚合结果，不返回文档
+	const aggName, hitsName = "date_histogram", "top_hits"
+	minDocCount := 1
+	histogram := types.Aggregations{
+		DateHistogram: &types.DateHistogramAggregation{
+			Field:            &field,
+			CalendarInterval: &interval,
+			Format:           &format,
+			MinDocCount:      &minDocCount,
+			Order:            map[string]sortorder.SortOrder{"_key": sortorder.Desc},
+		},
+	}
+	if topHits > 0 {
+		histogram.Aggregations = map[string]types.Aggregations{
+			hitsName: {TopHits: &types.TopHitsAggregation{
+				Size:    &topHits,
+				Sort:    []types.SortCombinations{types.SortOptions{SortOptions: map[string]types.FieldSort{field: {Order: &sortorder.Desc}}}},
+				Source_: types.SourceFilter{Includes: sourceIncludes},
+			}},
+		}
+	}
+	req := &search.Request{
+		Query:        query,
+		Aggregations: map[string]types.Aggregations{aggName: histogram},
+	}
+	res, err := global.ESClient.Search().
+		Index(elasticsearch.ArticleIndex()).
+		Request(req).
+		Size(0).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 2、解析分桶，聚合缺失或类型不符时返回错误，与 TermsCount 一致，避免调用方把异常结果当作“没有数据”
+	agg, ok := res.Aggregations[aggName].(*types.DateHistogramAggregate)
+	if !ok {
+		return nil, fmt.Errorf("date histogram on %s: unexpected result %T", field, res.Aggregations[aggName])
+	}
+	buckets, ok := agg.Buckets.([]types.DateHistogramBucket)
+	if !ok {
+		return nil, fmt.Errorf("date histogram on %s: unexpected buckets %T", field, agg.Buckets)
+	}
+	list := make([]DateBucket, 0, len(buckets))
+	for _, b := range buckets {
+		bucket := DateBucket{Count: b.DocCount}
+		if b.KeyAsString != nil {
+			bucket.Key = *b.KeyAsString
+		}
+		if topHits > 0 {
+			top, ok := b.Aggregations[hitsName].(*types.TopHitsAggregate)
+			if !ok {
+				return nil, fmt.Errorf("date histogram on %s: unexpected top hits %T", field, b.Aggregations[hitsName])
+			}
+			bucket.Hits = top.Hits.Hits
+		}
+		list = append(list, bucket)
+	}
+	return list, nil
+}
@@ -0,0 +1,91 @@
+package elasticSearch
+
+import (
+	"context"
+	"personal_blog/global"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// scrollKeepAlive 滚动查询上下文的保留时长
+const scrollKeepAlive = "1m"
+
+// scrollBatchSize 滚动查询每批返回的文档数
+const scrollBatchSize = 1000
+
+// ScrollHits 通过滚动查询逐批读取索引中符合条件的文档，query 为 nil 时读取全部
+// - source 指定返回的文档内容：true 返回全部字段，false 只返回文档ID，也可传入 types.SourceFilter 只取部分字段
+// - fn 返回错误时立即停止读取
+func ScrollHits(
+	ctx context.Context,
+	index string,
+	query *types.Query,
+	source types.SourceConfig,
+	fn func(hits []types.Hit) error,
+) error {
+	// 1、首次查询
+	size := scrollBatchSize
+	res, err := global.ESClient.Search().
+		Index(index).
+		Query(queryOrMatchAll(query)).
+		Size(size).
+		Source_(source).
+		Scroll(scrollKeepAlive).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	scrollID := res.ScrollId_
+	hits := res.Hits.Hits
+	// 2、结束时清理滚动上下文
+	defer func() {
+		if scrollID != nil {
+			_, _ = global.ESClient.ClearScroll().ScrollId(*scrollID).Do(context.TODO())
+		}
+	}()
+	// 3、逐批处理，直到没有更多结果
+	for len(hits) > 0 {
+		if err := fn(hits); err != nil {
+			return err
+		}
+		if scrollID == nil {
+			break
+		}
+		next, err := global.ESClient.Scroll().
+			ScrollId(*scrollID).
+			Scroll(scrollKeepAlive).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+		scrollID = next.ScrollId_
+		hits = next.Hits.Hits
+	}
+	return nil
+}
+
+// ScrollIDs 通过滚动查询取出索引中符合条件的全部文档ID，query 为 nil 时取全部
+func ScrollIDs(
+	ctx context.Context,
+	index string,
+	query *types.Query,
+) ([]string, error) {
+	var ids []string
+	err := ScrollHits(ctx, index, query, false, func(hits []types.Hit) error {
+		for _, hit := range hits {
+			if hit.Id_ != nil {
+				ids = append(ids, *hit.Id_)
+			}
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// queryOrMatchAll 查询条件为空时匹配全部文档
+func queryOrMatchAll(query *types.Query) *types.Query {
+	if query != nil {
+		return query
+	}
+	return &types.Query{MatchAll: &types.MatchAllQuery{}}
+}
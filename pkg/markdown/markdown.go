@@ -0,0 +1,296 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	nethtml "golang.org/x/net/html"
+)
+
+// Heading 目录项
+// - 目录按标题层级嵌套，跳级的标题（如 h2 下直接出现 h4）挂在最近的上级标题下
+type Heading struct {
+	Level    int       `json:"level"`              // 标题层级 1~6
+	ID       string    `json:"id"`                 // 锚点ID，与渲染后 HTML 中标题的 id 一致
+	Title    string    `json:"title"`              // 标题文本
+	Children []Heading `json:"children,omitempty"` // 下级标题
+}
+
+// Document Markdown 渲染结果
+type Document struct {
+	HTML   string    // 经过安全过滤的 HTML
+	TOC    []Heading // 目录
+	Images []string  // 正文中引用的图片地址（按出现顺序去重）
+}
+
+// engine Markdown 解析与渲染器（并发安全）
+// - 支持 GFM（表格、删除线、任务列表、自动链接），标题自动生成锚点ID
+// - 原始 HTML 照常输出，由 policy 统一过滤
+var engine = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// policy HTML 安全过滤策略（并发安全）
+// - 在 UGC 策略基础上保留标题锚点、代码块语言与任务列表复选框
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}()
+
+// Render 解析 Markdown，返回过滤后的 HTML、目录与图片地址
+func Render(source string) (Document, error) {
+	src := []byte(source)
+	// 1、解析语法树
+	doc := parse(src)
+	// 2、渲染并过滤 HTML
+	var buf bytes.Buffer
+	if err := engine.Renderer().Render(&buf, src, doc); err != nil {
+		return Document{}, err
+	}
+	// 3、从语法树中提取目录与图片
+	var headings []Heading
+	images := newImageSet()
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Heading:
+			headings = append(headings, Heading{Level: node.Level, ID: headingID(node), Title: nodeText(node, src)})
+			return ast.WalkSkipChildren, nil
+		default:
+			images.collect(n, src)
+		}
+		return ast.WalkContinue, nil
+	})
+	return Document{
+		HTML:   policy.Sanitize(buf.String()),
+		TOC:    nestHeadings(headings),
+		Images: images.list,
+	}, nil
+}
+
+// Images 提取 Markdown 中引用的全部图片地址（按出现顺序去重）
+// - 包括行内图片 ![alt](url)、引用式图片 ![alt][ref] 与 HTML <img> 标签
+func Images(source string) []string {
+	src := []byte(source)
+	doc := parse(src)
+	images := newImageSet()
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			images.collect(n, src)
+		}
+		return ast.WalkContinue, nil
+	})
+	return images.list
+}
+
+// Excerpt 提取正文开头的纯文本作为摘要，超过 limit 个字符时截断并追加省略号
+// - 只取段落文本，跳过标题、代码块、图片与 HTML
+func Excerpt(source string, limit int) string {
+	src := []byte(source)
+	doc := parse(src)
+	var parts []string
+	length := 0
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || length >= limit {
+			return ast.WalkContinue, nil
+		}
+		if _, ok := n.(*ast.Paragraph); !ok {
+			return ast.WalkContinue, nil
+		}
+		if t := nodeText(n, src); t != "" {
+			parts = append(parts, t)
+			length += len([]rune(t))
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	excerpt := []rune(strings.Join(parts, " "))
+	if len(excerpt) <= limit {
+		return string(excerpt)
+	}
+	return string(excerpt[:limit]) + "…"
+}
+
+// parse 解析 Markdown 语法树，每次解析使用独立的锚点ID生成器
+func parse(src []byte) ast.Node {
+	ctx := parser.NewContext(parser.WithIDs(&headingIDs{used: make(map[string]struct{})}))
+	return engine.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
+}
+
+// headingIDs 标题锚点ID生成器
+// - 保留各语言的字母与数字（中文标题不会被清空），空白转为“-”，其余符号去掉
+// - 同一文档内重复的ID追加 -1、-2 …… 后缀
+type headingIDs struct {
+	used map[string]struct{}
+}
+
+func (h *headingIDs) Generate(value []byte, _ ast.NodeKind) []byte {
+	// 1、规范化标题文本
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(string(value)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r) || r == '-':
+			b.WriteByte('-')
+		case r == '_':
+			b.WriteByte('_')
+		}
+	}
+	id := strings.Trim(b.String(), "-")
+	if id == "" {
+		id = "heading"
+	}
+	// 2、去重
+	unique := id
+	for i := 1; ; i++ {
+		if _, ok := h.used[unique]; !ok {
+			break
+		}
+		unique = id + "-" + strconv.Itoa(i)
+	}
+	h.used[unique] = struct{}{}
+	return []byte(unique)
+}
+
+func (h *headingIDs) Put(value []byte) {
+	h.used[string(value)] = struct{}{}
+}
+
+// imageSet 按出现顺序收集去重后的图片地址
+type imageSet struct {
+	seen map[string]struct{}
+	list []string
+}
+
+func newImageSet() *imageSet {
+	return &imageSet{seen: make(map[string]struct{})}
+}
+
+// collect 收集单个语法树节点中的图片地址
+// - 引用式图片在解析阶段已被解析为普通图片节点
+// - HTML 块与行内 HTML 需要再解析其中的 <img> 标签
+func (s *imageSet) collect(n ast.Node, src []byte) {
+	switch node := n.(type) {
+	case *ast.Image:
+		s.add(string(node.Destination))
+	case *ast.HTMLBlock:
+		var raw bytes.Buffer
+		lines := node.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			raw.Write(segment.Value(src))
+		}
+		if node.HasClosure() {
+			raw.Write(node.ClosureLine.Value(src))
+		}
+		s.addHTML(raw.String())
+	case *ast.RawHTML:
+		var raw bytes.Buffer
+		for i := 0; i < node.Segments.Len(); i++ {
+			segment := node.Segments.At(i)
+			raw.Write(segment.Value(src))
+		}
+		s.addHTML(raw.String())
+	}
+}
+
+// addHTML 解析 HTML 片段中 <img> 标签的 src
+func (s *imageSet) addHTML(fragment string) {
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(fragment))
+	for {
+		switch tokenizer.Next() {
+		case nethtml.ErrorToken:
+			return
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.Data != "img" {
+				continue
+			}
+			for _, attr := range token.Attr {
+				if attr.Key == "src" {
+					s.add(attr.Val)
+				}
+			}
+		}
+	}
+}
+
+func (s *imageSet) add(url string) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return
+	}
+	if _, ok := s.seen[url]; ok {
+		return
+	}
+	s.seen[url] = struct{}{}
+	s.list = append(s.list, url)
+}
+
+// headingID 标题自动生成的锚点ID
+func headingID(node *ast.Heading) string {
+	if id, ok := node.AttributeString("id"); ok {
+		if b, ok := id.([]byte); ok {
+			return string(b)
+		}
+	}
+	return ""
+}
+
+// nodeText 节点内的纯文本（去掉强调、链接、行内代码等标记）
+func nodeText(n ast.Node, src []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := c.(type) {
+		case *ast.Image:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			b.Write(node.Segment.Value(src))
+			if node.SoftLineBreak() || node.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(node.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
+
+// nestHeadings 将按出现顺序排列的标题组装为嵌套目录
+func nestHeadings(flat []Heading) []Heading {
+	var out []Heading
+	for i := 0; i < len(flat); {
+		h := flat[i]
+		// 1、紧随其后、层级更深的标题都是它的下级
+		j := i + 1
+		for j < len(flat) && flat[j].Level > h.Level {
+			j++
+		}
+		h.Children = nestHeadings(flat[i+1 : j])
+		out = append(out, h)
+		i = j
+	}
+	return out
+}
@@ -0,0 +1,220 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    []string // 过滤后必须保留的片段
+		notWant []string // 过滤后不能出现的片段
+	}{
+		{
+			name:    "去除 script 标签",
+			source:  "hello\n\n<script>alert(1)</script>\n",
+			want:    []string{"<p>hello</p>"},
+			notWant: []string{"<script", "alert(1)"},
+		},
+		{
+			name:    "去除行内 script 标签",
+			source:  "hello <script>alert(1)</script> world",
+			notWant: []string{"<script", "</script>"},
+		},
+		{
+			name:    "去除 onerror 事件属性",
+			source:  `<img src="x.png" onerror="alert(1)">`,
+			want:    []string{`src="x.png"`},
+			notWant: []string{"onerror", "alert(1)"},
+		},
+		{
+			name:    "去除 javascript 链接",
+			source:  "[click](javascript:alert(1))",
+			notWant: []string{"javascript:"},
+		},
+		{
+			name:    "去除 iframe 与 style",
+			source:  "<iframe src=\"https://evil.example\"></iframe>\n\n<p style=\"color:red\">x</p>\n",
+			notWant: []string{"<iframe", "style="},
+		},
+		{
+			name:   "保留标题锚点",
+			source: "# Hello World",
+			want:   []string{`<h1 id="hello-world">Hello World</h1>`},
+		},
+		{
+			name:    "保留代码块语言",
+			source:  "```go\nfmt.Println(1)\n```",
+			want:    []string{`<code class="language-go">`},
+			notWant: []string{"<pre class"},
+		},
+		{
+			name:    "去除不合法的代码块语言",
+			source:  "```go\" onclick=\"x\nfmt.Println(1)\n```",
+			notWant: []string{"onclick"},
+		},
+		{
+			name:   "保留任务列表复选框",
+			source: "- [x] done\n- [ ] todo",
+			want:   []string{`type="checkbox"`, "checked", "disabled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(doc.HTML, s) {
+					t.Errorf("HTML 缺少 %q: %s", s, doc.HTML)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(doc.HTML, s) {
+					t.Errorf("HTML 不应包含 %q: %s", s, doc.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHeadingIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		ids    []string // 按出现顺序的锚点ID
+	}{
+		{name: "英文标题", source: "# Hello World", ids: []string{"hello-world"}},
+		{name: "中文标题", source: "## 安装 与 配置", ids: []string{"安装-与-配置"}},
+		{name: "去掉符号保留下划线", source: "## Go 1.23: what's_new?", ids: []string{"go-123-whats_new"}},
+		{name: "只有符号", source: "## !!!", ids: []string{"heading"}},
+		{name: "重复标题追加序号", source: "## 示例\n\n## 示例\n\n### 示例", ids: []string{"示例", "示例-1", "示例-2"}},
+		{name: "与已生成的带序号ID冲突", source: "## a-1\n\n## a\n\n## a", ids: []string{"a-1", "a", "a-2"}},
+		{name: "强调与行内代码", source: "## **Use** `go test`", ids: []string{"use-go-test"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			var ids []string
+			var walk func([]Heading)
+			walk = func(list []Heading) {
+				for _, h := range list {
+					ids = append(ids, h.ID)
+					walk(h.Children)
+				}
+			}
+			walk(doc.TOC)
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Fatalf("目录ID = %q, 期望 %q", ids, tt.ids)
+			}
+			// 目录中的ID必须与渲染后 HTML 中标题的 id 一致，否则锚点无法跳转
+			for _, id := range ids {
+				if !strings.Contains(doc.HTML, `id="`+id+`"`) {
+					t.Errorf("HTML 中没有 id=%q: %s", id, doc.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestNestHeadings(t *testing.T) {
+	h := func(level int, id string, children ...Heading) Heading {
+		return Heading{Level: level, ID: id, Children: children}
+	}
+	tests := []struct {
+		name string
+		flat []Heading
+		want []Heading
+	}{
+		{name: "没有标题", flat: nil, want: nil},
+		{
+			name: "同级标题",
+			flat: []Heading{h(2, "a"), h(2, "b")},
+			want: []Heading{h(2, "a"), h(2, "b")},
+		},
+		{
+			name: "逐级嵌套",
+			flat: []Heading{h(1, "a"), h(2, "b"), h(3, "c"), h(2, "d")},
+			want: []Heading{h(1, "a", h(2, "b", h(3, "c")), h(2, "d"))},
+		},
+		{
+			name: "跳级标题挂在最近的上级下",
+			flat: []Heading{h(2, "a"), h(4, "b"), h(3, "c"), h(2, "d")},
+			want: []Heading{h(2, "a", h(4, "b"), h(3, "c")), h(2, "d")},
+		},
+		{
+			name: "以较深层级开头",
+			flat: []Heading{h(3, "a"), h(2, "b"), h(3, "c")},
+			want: []Heading{h(3, "a"), h(2, "b", h(3, "c"))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nestHeadings(tt.flat); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nestHeadings() = %+v, 期望 %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImages(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{name: "没有图片", source: "hello", want: nil},
+		{name: "行内图片", source: "![a](/a.png)", want: []string{"/a.png"}},
+		{name: "引用式图片", source: "![a][ref]\n\n[ref]: https://cdn.example/a.png", want: []string{"https://cdn.example/a.png"}},
+		{name: "简写引用式图片", source: "![logo]\n\n[logo]: /logo.png", want: []string{"/logo.png"}},
+		{name: "HTML 块中的 img", source: "<div>\n<img src=\"/b.png\" alt=\"b\">\n</div>", want: []string{"/b.png"}},
+		{name: "行内 HTML 的 img", source: "text <img src=\"/c.png\"/> text", want: []string{"/c.png"}},
+		{
+			name:   "按出现顺序去重",
+			source: "![a](/a.png)\n\n<img src=\"/b.png\">\n\n![a][ref] ![again](/a.png)\n\n[ref]: /c.png",
+			want:   []string{"/a.png", "/b.png", "/c.png"},
+		},
+		{name: "代码块中的图片不计入", source: "```\n![a](/a.png)\n<img src=\"/b.png\">\n```", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Images(tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Images() = %q, 期望 %q", got, tt.want)
+			}
+			doc, err := Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !reflect.DeepEqual(doc.Images, tt.want) {
+				t.Errorf("Render().Images = %q, 期望 %q", doc.Images, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		limit  int
+		want   string
+	}{
+		{name: "跳过标题与代码块", source: "# Title\n\n```\ncode\n```\n\nfirst **para**\n\nsecond", limit: 100, want: "first para second"},
+		{name: "超出长度截断", source: "你好世界你好世界", limit: 4, want: "你好世界…"},
+		{name: "跳过图片", source: "![alt](/a.png) text", limit: 100, want: "text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Excerpt(tt.source, tt.limit); got != tt.want {
+				t.Errorf("Excerpt() = %q, 期望 %q", got, tt.want)
+			}
+		})
+	}
+}
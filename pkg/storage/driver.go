@@ -31,9 +31,11 @@ type StorageObject struct {
 // 调用约定：
 // - Delete(ctx, key)：key 为存储键（不含前缀）。，资源不存在必须视为成功（幂等）
 // - Upload(ctx, r, filename)：r 为流式数据源，filename 用作对象名/建议名；实现应尽量避免整文件缓冲。
+// - Download(ctx, key)：key 为存储键（不含前缀），返回对象内容的流，调用方负责关闭。
 // - Name()：返回驱动名（如 "local"、"qiniu"），用于日志与分支控制。
 type Driver interface {
 	Name() string
 	Delete(ctx context.Context, key string) error
 	Upload(ctx context.Context, r io.Reader, filename string) (StorageObject, error)
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
 }
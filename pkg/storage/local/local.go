@@ -44,6 +44,12 @@ func (d *Driver) Delete(_ context.Context, key string) error {
 	return nil
 }
 
+// Download 打开本地文件，返回文件内容的流
+func (d *Driver) Download(_ context.Context, key string) (io.ReadCloser, error) {
+	root := strings.TrimSpace(global.Config.Static.Path)
+	return os.Open(filepath.Join(root, key))
+}
+
 // Upload 暂不改造上传流程，保留骨架，后续可切换到统一驱动上传
 func (d *Driver) Upload(
 	_ context.Context,
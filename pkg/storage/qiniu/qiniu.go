@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/qiniu/go-sdk/v7/auth"
 	"io"
+	"net/http"
 	"path/filepath"
 	"personal_blog/global"
 	"personal_blog/pkg/storage"
@@ -123,6 +124,36 @@ func (d *Driver) Upload(
 	}, nil
 }
 
+// Download 通过配置的域名下载对象
+// 使用带时效签名的私有链接，公开空间同样可以访问
+func (d *Driver) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	mac, _, err := credentialsFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	domain := strings.TrimSuffix(strings.TrimSpace(global.Config.Storage.Qiniu.Domain), "/")
+	if domain == "" {
+		return nil, errors.New("qiniu domain not configured")
+	}
+	if !strings.HasPrefix(domain, "http://") && !strings.HasPrefix(domain, "https://") {
+		domain = "https://" + domain
+	}
+	deadline := time.Now().Add(time.Hour).Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, qstorage.MakePrivateURLv2(mac, domain, key, deadline), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		_ = res.Body.Close()
+		return nil, fmt.Errorf("qiniu download %s: %s", key, res.Status)
+	}
+	return res.Body, nil
+}
+
 // sdkConfig 构建七牛 SDK 配置，挂载共享 HTTP 连接的 Transport
 func sdkConfig() *qstorage.Config {
 	useHTTPS := strings.HasPrefix(global.Config.Storage.Qiniu.Domain, "https://")
@@ -0,0 +1,149 @@
+package util
+
+import "strings"
+
+// DiffOp 行差异类型
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"  // 未变化
+	DiffInsert DiffOp = "insert" // 新增
+	DiffDelete DiffOp = "delete" // 删除
+)
+
+// DiffLine 单行差异
+type DiffLine struct {
+	Op      DiffOp `json:"op"`       // 差异类型
+	Text    string `json:"text"`     // 行内容
+	OldLine int    `json:"old_line"` // 在旧文本中的行号（从1开始，新增行为0）
+	NewLine int    `json:"new_line"` // 在新文本中的行号（从1开始，删除行为0）
+}
+
+// maxDiffEdits 逐行比较的最大编辑距离，超过时按整段替换处理
+// - 回溯需要保存每一步的搜索状态，内存约为编辑距离的平方；两篇完全不同的长文若不设上限会占用数 GB 内存
+const maxDiffEdits = 1000
+
+// DiffLines 按行比较新旧文本，返回逐行差异（Myers 差分算法，结果为最短编辑脚本）
+// - 编辑距离超过 maxDiffEdits 时，中间变化的部分按“整段删除再整段新增”返回
+func DiffLines(oldText, newText string) []DiffLine {
+	a, b := splitLines(oldText), splitLines(newText)
+	n, m := len(a), len(b)
+	lines := make([]DiffLine, 0, max(n, m))
+	// 1、相同的开头与结尾直接视为未变化，只比较中间部分
+	prefix := 0
+	for prefix < n && prefix < m && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < n-prefix && suffix < m-prefix && a[n-1-suffix] == b[m-1-suffix] {
+		suffix++
+	}
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: a[i], OldLine: i + 1, NewLine: i + 1})
+	}
+	// 2、比较中间部分
+	lines = append(lines, myersDiff(a[prefix:n-suffix], b[prefix:m-suffix], prefix)...)
+	// 3、相同的结尾
+	for i := suffix; i > 0; i-- {
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: a[n-i], OldLine: n - i + 1, NewLine: m - i + 1})
+	}
+	return lines
+}
+
+// myersDiff 用 Myers 差分算法比较 a、b，base 为两者在原文中前面相同的行数，用于计算行号
+// - 编辑距离超过 maxDiffEdits 时退化为整段替换
+func myersDiff(a, b []string, base int) []DiffLine {
+	n, m := len(a), len(b)
+	if n+m == 0 {
+		return nil
+	}
+	maxD := min(n+m, maxDiffEdits)
+	// 1、前向搜索：v[k] 记录对角线 k 上能到达的最远 x
+	// trace 保存每一步开始前的 v，只保存该步会用到的对角线 -d-1..d+1，下标为 k+d+1
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset] // 从上方下移：插入
+			} else {
+				x = v[k-1+offset] + 1 // 从左侧右移：删除
+			}
+			y := x - k
+			// 1.a 沿对角线跳过相同的行
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return replaceLines(a, b, base)
+	}
+	// 2、回溯：从终点倒推出编辑路径
+	var reversed []DiffLine
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		w := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && w[k-1+d+1] < w[k+1+d+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := w[prevK+d+1]
+		prevY := prevX - prevK
+		// 2.a 对角线部分为相同行
+		for x > prevX && y > prevY {
+			reversed = append(reversed, DiffLine{Op: DiffEqual, Text: a[x-1], OldLine: base + x, NewLine: base + y})
+			x--
+			y--
+		}
+		if d > 0 {
+			// 2.b 纵向为插入，横向为删除
+			if x == prevX {
+				reversed = append(reversed, DiffLine{Op: DiffInsert, Text: b[y-1], NewLine: base + y})
+			} else {
+				reversed = append(reversed, DiffLine{Op: DiffDelete, Text: a[x-1], OldLine: base + x})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	// 3、反转为正序
+	lines := make([]DiffLine, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		lines = append(lines, reversed[i])
+	}
+	return lines
+}
+
+// replaceLines 整段替换：先删除 a 的全部行，再新增 b 的全部行
+func replaceLines(a, b []string, base int) []DiffLine {
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	for i, text := range a {
+		lines = append(lines, DiffLine{Op: DiffDelete, Text: text, OldLine: base + i + 1})
+	}
+	for i, text := range b {
+		lines = append(lines, DiffLine{Op: DiffInsert, Text: text, NewLine: base + i + 1})
+	}
+	return lines
+}
+
+// splitLines 将文本拆分为行，兼容 \r\n 换行；空文本返回空切片
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		old   string
+		new   string
+		edits int // 期望的新增+删除行数（最短编辑脚本）
+	}{
+		{name: "都为空", old: "", new: "", edits: 0},
+		{name: "完全相同", old: "a\nb\nc", new: "a\nb\nc", edits: 0},
+		{name: "从空文本新增", old: "", new: "a\nb", edits: 2},
+		{name: "全部删除", old: "a\nb", new: "", edits: 2},
+		{name: "中间插入", old: "a\nc", new: "a\nb\nc", edits: 1},
+		{name: "中间删除", old: "a\nb\nc", new: "a\nc", edits: 1},
+		{name: "修改一行", old: "a\nb\nc", new: "a\nx\nc", edits: 2},
+		{name: "交错修改", old: "a\nb\nc\na\nb\nb\na", new: "c\nb\na\nb\na\nc", edits: 5},
+		{name: "兼容 CRLF 与结尾换行", old: "a\r\nb\r\n", new: "a\nb", edits: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := DiffLines(tt.old, tt.new)
+			checkDiff(t, tt.old, tt.new, lines)
+			if got := countEdits(lines); got != tt.edits {
+				t.Errorf("编辑行数 = %d, 期望 %d", got, tt.edits)
+			}
+		})
+	}
+}
+
+func TestDiffLinesFallback(t *testing.T) {
+	// 编辑距离超过上限时，中间部分按整段替换返回，首尾相同的行仍为未变化
+	var oldLines, newLines []string
+	for i := 0; i < maxDiffEdits; i++ {
+		oldLines = append(oldLines, fmt.Sprintf("old %d", i))
+		newLines = append(newLines, fmt.Sprintf("new %d", i))
+	}
+	oldText := "head\n" + strings.Join(oldLines, "\n") + "\ntail"
+	newText := "head\n" + strings.Join(newLines, "\n") + "\ntail"
+	lines := DiffLines(oldText, newText)
+	checkDiff(t, oldText, newText, lines)
+	if len(lines) != 2*maxDiffEdits+2 {
+		t.Fatalf("差异行数 = %d, 期望 %d", len(lines), 2*maxDiffEdits+2)
+	}
+	if lines[0].Op != DiffEqual || lines[len(lines)-1].Op != DiffEqual {
+		t.Errorf("首尾相同的行应为未变化")
+	}
+	for i, line := range lines[1 : len(lines)-1] {
+		want := DiffDelete
+		if i >= maxDiffEdits {
+			want = DiffInsert
+		}
+		if line.Op != want {
+			t.Fatalf("第 %d 行差异类型 = %s, 期望 %s", i+2, line.Op, want)
+		}
+	}
+}
+
+// checkDiff 校验差异能还原出新旧文本，且行号连续
+func checkDiff(t *testing.T, oldText, newText string, lines []DiffLine) {
+	t.Helper()
+	var oldOut, newOut []string
+	for _, line := range lines {
+		switch line.Op {
+		case DiffEqual:
+			oldOut = append(oldOut, line.Text)
+			newOut = append(newOut, line.Text)
+		case DiffDelete:
+			oldOut = append(oldOut, line.Text)
+		case DiffInsert:
+			newOut = append(newOut, line.Text)
+		}
+		if line.Op != DiffInsert && line.OldLine != len(oldOut) {
+			t.Fatalf("旧行号 = %d, 期望 %d", line.OldLine, len(oldOut))
+		}
+		if line.Op != DiffDelete && line.NewLine != len(newOut) {
+			t.Fatalf("新行号 = %d, 期望 %d", line.NewLine, len(newOut))
+		}
+	}
+	if got, want := strings.Join(oldOut, "\n"), strings.Join(splitLines(oldText), "\n"); got != want {
+		t.Errorf("还原旧文本 = %q, 期望 %q", got, want)
+	}
+	if got, want := strings.Join(newOut, "\n"), strings.Join(splitLines(newText), "\n"); got != want {
+		t.Errorf("还原新文本 = %q, 期望 %q", got, want)
+	}
+}
+
+// countEdits 统计新增与删除的行数
+func countEdits(lines []DiffLine) int {
+	n := 0
+	for _, line := range lines {
+		if line.Op != DiffEqual {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,25 @@
+package task
+
+import (
+	"context"
+	"personal_blog/global"
+	"personal_blog/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExpireArticlePromotions 取消到期的文章置顶与精选
+func ExpireArticlePromotions() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	articleSvc := service.GroupApp.SystemServiceSupplier.GetArticleSvc()
+	count, err := articleSvc.ExpirePromotions(ctx)
+	if err != nil {
+		global.Log.Error("取消到期的置顶/精选失败", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		global.Log.Info("取消到期的置顶/精选成功", zap.Int64("count", count))
+	}
+}
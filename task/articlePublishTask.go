@@ -0,0 +1,25 @@
+package task
+
+import (
+	"context"
+	"personal_blog/global"
+	"personal_blog/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PublishScheduledArticles 发布到期的定时文章
+func PublishScheduledArticles() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	articleSvc := service.GroupApp.SystemServiceSupplier.GetArticleSvc()
+	count, err := articleSvc.PublishScheduled(ctx)
+	if err != nil {
+		global.Log.Error("发布定时文章失败", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		global.Log.Info("发布定时文章成功", zap.Int64("count", count))
+	}
+}
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"context"
+	"personal_blog/global"
+	"personal_blog/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReconcileArticleTaxonomy 核对分类/标签计数，按配置决定是否自动修复
+func ReconcileArticleTaxonomy() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	taxonomySvc := service.GroupApp.SystemServiceSupplier.GetArticleTaxonomySvc()
+	res, err := taxonomySvc.Reconcile(ctx, global.Config.Article.ReconcileRepair)
+	if err != nil {
+		global.Log.Error("分类/标签计数对账失败", zap.Error(err))
+		return
+	}
+	if len(res.Categories) == 0 && len(res.Tags) == 0 {
+		return
+	}
+	global.Log.Warn("分类/标签计数存在偏差",
+		zap.Any("categories", res.Categories),
+		zap.Any("tags", res.Tags),
+		zap.Bool("repaired", res.Repaired))
+}
@@ -0,0 +1,25 @@
+package task
+
+import (
+	"context"
+	"personal_blog/global"
+	"personal_blog/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// FlushArticleViews 将 Redis 中累计的文章浏览增量批量刷入 ES
+func FlushArticleViews() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	viewSvc := service.GroupApp.SystemServiceSupplier.GetArticleViewSvc()
+	count, err := viewSvc.FlushViews(ctx)
+	if err != nil {
+		global.Log.Error("刷新文章浏览量失败", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		global.Log.Info("刷新文章浏览量成功", zap.Int("count", count))
+	}
+}
@@ -1,8 +1,58 @@
 package task
 
-import "github.com/robfig/cron/v3"
+import (
+	"personal_blog/global"
 
-func RegisterScheduledTasks(c *cron.Cron) error {
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// defaultViewFlushSpec 未配置时浏览量的刷新周期
+	defaultViewFlushSpec = "@every 1m"
+	// defaultPublishSpec 未配置时定时文章的扫描周期
+	defaultPublishSpec = "@every 1m"
+	// defaultReconcileSpec 未配置时分类/标签计数的对账周期
+	defaultReconcileSpec = "@daily"
+	// defaultOutboxSpec 未配置时 ES 发件箱的重试周期
+	defaultOutboxSpec = "@every 30s"
+)
 
+func RegisterScheduledTasks(c *cron.Cron) error {
+	// 文章浏览量：定期把 Redis 中的增量批量刷入 ES
+	viewFlushSpec := global.Config.Article.ViewFlushSpec
+	if viewFlushSpec == "" {
+		viewFlushSpec = defaultViewFlushSpec
+	}
+	if _, err := c.AddFunc(viewFlushSpec, FlushArticleViews); err != nil {
+		return err
+	}
+	// 定时发布：定期发布到期的定时文章
+	publishSpec := global.Config.Article.PublishSpec
+	if publishSpec == "" {
+		publishSpec = defaultPublishSpec
+	}
+	if _, err := c.AddFunc(publishSpec, PublishScheduledArticles); err != nil {
+		return err
+	}
+	// 置顶/精选到期：与定时发布使用相同的扫描周期
+	if _, err := c.AddFunc(publishSpec, ExpireArticlePromotions); err != nil {
+		return err
+	}
+	// 计数对账：定期按 ES 实际数据核对分类/标签计数
+	reconcileSpec := global.Config.Article.ReconcileSpec
+	if reconcileSpec == "" {
+		reconcileSpec = defaultReconcileSpec
+	}
+	if _, err := c.AddFunc(reconcileSpec, ReconcileArticleTaxonomy); err != nil {
+		return err
+	}
+	// ES 发件箱：定期重试同步失败的文章写入
+	outboxSpec := global.Config.ES.OutboxSpec
+	if outboxSpec == "" {
+		outboxSpec = defaultOutboxSpec
+	}
+	if _, err := c.AddFunc(outboxSpec, DispatchEsOutbox); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,25 @@
+package task
+
+import (
+	"context"
+	"personal_blog/global"
+	"personal_blog/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DispatchEsOutbox 重试发件箱中尚未同步到 ES 的文章操作
+func DispatchEsOutbox() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	outboxSvc := service.GroupApp.SystemServiceSupplier.GetEsOutboxSvc()
+	count, err := outboxSvc.DispatchDue(ctx)
+	if err != nil {
+		global.Log.Error("同步ES发件箱失败", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		global.Log.Info("同步ES发件箱成功", zap.Int("count", count))
+	}
+}